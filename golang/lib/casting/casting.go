@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package casting
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"reflect"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func GetService[S services.Service](networkCtx *networks.NetworkContext, serviceId services.ServiceID) (S, error) {
+	var emptyResult S
+	uncastedService, err := networkCtx.GetService(serviceId)
+	if err != nil {
+		return emptyResult, stacktrace.Propagate(err, "An error occurred getting service '%v'", serviceId)
+	}
+	castedService, err := CastService[S](uncastedService)
+	if err != nil {
+		return emptyResult, stacktrace.Propagate(err, "An error occurred casting service '%v'", serviceId)
+	}
+	return castedService, nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func CastService[S services.Service](uncastedService services.Service) (S, error) {
+	castedService, ok := uncastedService.(S)
+	if !ok {
+		return castedService, stacktrace.NewError(
+			"Expected service to be of type '%v', but was of type '%T'",
+			getTypeName[S](),
+			uncastedService,
+		)
+	}
+	return castedService, nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func CastNetwork[N networks.Network](uncastedNetwork networks.Network) (N, error) {
+	castedNetwork, ok := uncastedNetwork.(N)
+	if !ok {
+		return castedNetwork, stacktrace.NewError(
+			"Expected network to be of type '%v', but was of type '%T'",
+			getTypeName[N](),
+			uncastedNetwork,
+		)
+	}
+	return castedNetwork, nil
+}
+
+// We can't use '%T' on a zero value of the type parameter, because that prints '<nil>' when it's an interface type
+func getTypeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package custom_params
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	rootParamPath = "<root>"
+	paramPathSeparator = "."
+	jsonNull = "null"
+	emptyJsonObject = "{}"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func ParseParams[P any](paramsJsonStr string) (*P, error) {
+	result := new(P)
+	resultValue := reflect.ValueOf(result).Elem()
+	if resultValue.Kind() != reflect.Struct {
+		return nil, stacktrace.NewError("Params type must be a struct, but was '%v'", resultValue.Type())
+	}
+
+	problems := []string{}
+	if err := decodeAndValidateStruct([]byte(paramsJsonStr), resultValue, "", &problems); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred decoding the params into type '%v'", resultValue.Type())
+	}
+	if len(problems) > 0 {
+		return nil, stacktrace.NewError(
+			"%v problems were found with the custom params:\n%v",
+			len(problems),
+			strings.Join(problems, "\n"),
+		)
+	}
+	return result, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Problems with the params are appended to the problems list so that the user gets all of them at once; a returned
+//  error indicates a badly-tagged params struct
+func decodeAndValidateStruct(structJson json.RawMessage, structValue reflect.Value, structPath string, problems *[]string) error {
+	fieldJsons := map[string]json.RawMessage{}
+	if err := json.Unmarshal(structJson, &fieldJsons); err != nil {
+		*problems = append(*problems, fmt.Sprintf("%v: expected a JSON object: %v", getDisplayPath(structPath), err))
+		return nil
+	}
+
+	specs, err := getFieldSpecs(structValue.Type())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the field specs for type '%v'", structValue.Type())
+	}
+
+	knownJsonNames := map[string]bool{}
+	for _, spec := range specs {
+		knownJsonNames[spec.jsonName] = true
+		fieldPath := joinParamPath(structPath, spec.jsonName)
+		fieldValue := structValue.Field(spec.fieldIndex)
+
+		fieldJson, found := fieldJsons[spec.jsonName]
+		if !found || string(fieldJson) == jsonNull {
+			if spec.isRequired {
+				*problems = append(*problems, fmt.Sprintf("%v: is required, but wasn't provided", fieldPath))
+				continue
+			}
+			switch {
+			case spec.defaultValueJson != nil:
+				fieldJson = spec.defaultValueJson
+			case fieldValue.Kind() == reflect.Struct:
+				// Decoded as an empty object so that the nested params' own defaults and requirements still apply
+				fieldJson = json.RawMessage(emptyJsonObject)
+			default:
+				continue
+			}
+		}
+
+		numProblemsBeforeDecoding := len(*problems)
+		if err := decodeAndValidateValue(fieldJson, fieldValue, fieldPath, problems); err != nil {
+			return stacktrace.Propagate(err, "An error occurred decoding param '%v'", fieldPath)
+		}
+		if len(*problems) > numProblemsBeforeDecoding {
+			continue
+		}
+		validateField(spec, fieldValue, fieldPath, problems)
+	}
+
+	// Sorted so that the problems are reported in a deterministic order
+	unknownJsonNames := []string{}
+	for jsonName := range fieldJsons {
+		if !knownJsonNames[jsonName] {
+			unknownJsonNames = append(unknownJsonNames, jsonName)
+		}
+	}
+	sort.Strings(unknownJsonNames)
+	for _, jsonName := range unknownJsonNames {
+		*problems = append(*problems, fmt.Sprintf("%v: isn't a known param", joinParamPath(structPath, jsonName)))
+	}
+	return nil
+}
+
+// Structs are decoded field-by-field wherever they appear (including behind pointers and inside slices and maps) so
+//  that their tags are applied exactly as the JSON schema describes; all other values are decoded as-is
+func decodeAndValidateValue(valueJson json.RawMessage, value reflect.Value, valuePath string, problems *[]string) error {
+	if !typeContainsStruct(value.Type()) {
+		if err := json.Unmarshal(valueJson, value.Addr().Interface()); err != nil {
+			*problems = append(*problems, fmt.Sprintf("%v: %v", getDisplayPath(valuePath), err))
+		}
+		return nil
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		if err := decodeAndValidateStruct(valueJson, value, valuePath, problems); err != nil {
+			return stacktrace.Propagate(err, "An error occurred decoding nested params '%v'", getDisplayPath(valuePath))
+		}
+	case reflect.Ptr:
+		if string(valueJson) == jsonNull {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		pointedToValue := reflect.New(value.Type().Elem())
+		if err := decodeAndValidateValue(valueJson, pointedToValue.Elem(), valuePath, problems); err != nil {
+			return stacktrace.Propagate(err, "An error occurred decoding the value pointed to by '%v'", getDisplayPath(valuePath))
+		}
+		value.Set(pointedToValue)
+	case reflect.Slice:
+		var elementJsons []json.RawMessage
+		if err := json.Unmarshal(valueJson, &elementJsons); err != nil {
+			*problems = append(*problems, fmt.Sprintf("%v: expected a JSON array: %v", getDisplayPath(valuePath), err))
+			return nil
+		}
+		if elementJsons == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		sliceValue := reflect.MakeSlice(value.Type(), len(elementJsons), len(elementJsons))
+		for i, elementJson := range elementJsons {
+			elementPath := fmt.Sprintf("%v[%v]", valuePath, i)
+			if err := decodeAndValidateValue(elementJson, sliceValue.Index(i), elementPath, problems); err != nil {
+				return stacktrace.Propagate(err, "An error occurred decoding element '%v'", elementPath)
+			}
+		}
+		value.Set(sliceValue)
+	case reflect.Map:
+		var elementJsons map[string]json.RawMessage
+		if err := json.Unmarshal(valueJson, &elementJsons); err != nil {
+			*problems = append(*problems, fmt.Sprintf("%v: expected a JSON object: %v", getDisplayPath(valuePath), err))
+			return nil
+		}
+		if elementJsons == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+		// Sorted so that the problems are reported in a deterministic order
+		keys := []string{}
+		for key := range elementJsons {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		mapValue := reflect.MakeMapWithSize(value.Type(), len(elementJsons))
+		for _, key := range keys {
+			elementPath := joinParamPath(valuePath, key)
+			elementValue := reflect.New(value.Type().Elem()).Elem()
+			if err := decodeAndValidateValue(elementJsons[key], elementValue, elementPath, problems); err != nil {
+				return stacktrace.Propagate(err, "An error occurred decoding element '%v'", elementPath)
+			}
+			mapValue.SetMapIndex(reflect.ValueOf(key).Convert(value.Type().Key()), elementValue)
+		}
+		value.Set(mapValue)
+	default:
+		// Should never happen because getFieldSpecs rejects all other types that contain structs
+		return stacktrace.NewError("Type '%v' contains a struct, but decoding it field-by-field isn't supported", value.Type())
+	}
+	return nil
+}
+
+func validateField(spec *fieldSpec, fieldValue reflect.Value, fieldPath string, problems *[]string) {
+	if spec.isRequired && fieldValue.Kind() == reflect.String && strings.TrimSpace(fieldValue.String()) == "" {
+		*problems = append(*problems, fmt.Sprintf("%v: is required, but was empty", fieldPath))
+	}
+
+	if len(spec.enumValues) > 0 {
+		isAllowedValue := false
+		for _, enumValue := range spec.enumValues {
+			if reflect.DeepEqual(fieldValue.Interface(), enumValue) {
+				isAllowedValue = true
+				break
+			}
+		}
+		if !isAllowedValue {
+			*problems = append(*problems, fmt.Sprintf(
+				"%v: value '%v' isn't one of the allowed values %v",
+				fieldPath,
+				fieldValue.Interface(),
+				spec.enumValues,
+			))
+		}
+	}
+
+	if spec.pattern != nil && !spec.pattern.MatchString(fieldValue.String()) {
+		*problems = append(*problems, fmt.Sprintf(
+			"%v: value '%v' doesn't match pattern '%v'",
+			fieldPath,
+			fieldValue.String(),
+			spec.pattern.String(),
+		))
+	}
+}
+
+func joinParamPath(parentPath string, jsonName string) string {
+	if parentPath == "" {
+		return jsonName
+	}
+	return parentPath + paramPathSeparator + jsonName
+}
+
+func getDisplayPath(paramPath string) string {
+	if paramPath == "" {
+		return rootParamPath
+	}
+	return paramPath
+}
@@ -0,0 +1,356 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package custom_params
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testDatabaseParams struct {
+	Host string `json:"host" required:"true"`
+	Port int    `json:"port" default:"5432"`
+}
+
+type testParams struct {
+	Image       string             `json:"image" required:"true" description:"The image to run"`
+	LogLevel    string             `json:"logLevel" default:"info" enum:"debug,info,warn"`
+	NumReplicas int                `json:"numReplicas" default:"1" enum:"1,3,5"`
+	Version     string             `json:"version" pattern:"^v[0-9]+$"`
+	Tags        []string           `json:"tags" default:"[\"a\", \"b\"]"`
+	Database    testDatabaseParams `json:"database"`
+	Ignored     string             `json:"-"`
+	unexported  string
+}
+
+func TestParseParams(t *testing.T) {
+	testCases := []struct {
+		name string
+		paramsJson string
+		expected *testParams
+		// Each of these must appear in the error; empty if parsing should succeed
+		expectedProblemSubstrings []string
+	}{
+		{
+			name: "defaults are applied",
+			paramsJson: `{"image": "nginx", "database": {"host": "db"}}`,
+			expected: &testParams{
+				Image:       "nginx",
+				LogLevel:    "info",
+				NumReplicas: 1,
+				Tags:        []string{"a", "b"},
+				Database:    testDatabaseParams{Host: "db", Port: 5432},
+			},
+		},
+		{
+			name: "provided values override defaults",
+			paramsJson: `{"image": "nginx", "logLevel": "debug", "numReplicas": 3, "version": "v2", "tags": [], "database": {"host": "db", "port": 1234}}`,
+			expected: &testParams{
+				Image:       "nginx",
+				LogLevel:    "debug",
+				NumReplicas: 3,
+				Version:     "v2",
+				Tags:        []string{},
+				Database:    testDatabaseParams{Host: "db", Port: 1234},
+			},
+		},
+		{
+			name: "null is treated as not provided",
+			paramsJson: `{"image": "nginx", "logLevel": null, "database": {"host": "db"}}`,
+			expected: &testParams{
+				Image:       "nginx",
+				LogLevel:    "info",
+				NumReplicas: 1,
+				Tags:        []string{"a", "b"},
+				Database:    testDatabaseParams{Host: "db", Port: 5432},
+			},
+		},
+		{
+			name: "missing and empty required params",
+			paramsJson: `{"image": "  "}`,
+			expectedProblemSubstrings: []string{
+				"2 problems were found",
+				"image: is required, but was empty",
+				"database.host: is required, but wasn't provided",
+			},
+		},
+		{
+			name: "enum and pattern violations",
+			paramsJson: `{"image": "nginx", "logLevel": "trace", "numReplicas": 2, "version": "2.0", "database": {"host": "db"}}`,
+			expectedProblemSubstrings: []string{
+				"3 problems were found",
+				"logLevel: value 'trace' isn't one of the allowed values [debug info warn]",
+				"numReplicas: value '2' isn't one of the allowed values [1 3 5]",
+				"version: value '2.0' doesn't match pattern '^v[0-9]+$'",
+			},
+		},
+		{
+			name: "wrong types",
+			paramsJson: `{"image": "nginx", "numReplicas": "three", "database": "db"}`,
+			expectedProblemSubstrings: []string{
+				"2 problems were found",
+				"numReplicas: json: cannot unmarshal string",
+				"database: expected a JSON object",
+			},
+		},
+		{
+			name: "unknown keys at any depth",
+			paramsJson: `{"image": "nginx", "imag": "typo", "Ignored": "x", "database": {"host": "db", "hostname": "db"}}`,
+			expectedProblemSubstrings: []string{
+				"3 problems were found",
+				"Ignored: isn't a known param",
+				"imag: isn't a known param",
+				"database.hostname: isn't a known param",
+			},
+		},
+		{
+			name: "not an object",
+			paramsJson: `[]`,
+			expectedProblemSubstrings: []string{
+				"<root>: expected a JSON object",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ParseParams[testParams](testCase.paramsJson)
+			if len(testCase.expectedProblemSubstrings) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error, but got: %v", err)
+				}
+				if !reflect.DeepEqual(result, testCase.expected) {
+					t.Fatalf("Expected params %+v, but got %+v", testCase.expected, result)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, but got params %+v", result)
+			}
+			for _, substring := range testCase.expectedProblemSubstrings {
+				if !strings.Contains(err.Error(), substring) {
+					t.Errorf("Expected the error to contain '%v', but got: %v", substring, err)
+				}
+			}
+		})
+	}
+}
+
+type testNestedShapesParams struct {
+	Pointer *testDatabaseParams           `json:"pointer"`
+	List    []testDatabaseParams          `json:"list"`
+	ByName  map[string]testDatabaseParams `json:"byName"`
+}
+
+func TestParseParamsNestedStructShapes(t *testing.T) {
+	testCases := []struct {
+		name string
+		paramsJson string
+		expected *testNestedShapesParams
+		// Each of these must appear in the error; empty if parsing should succeed
+		expectedProblemSubstrings []string
+	}{
+		{
+			name: "omitted shapes are left empty",
+			paramsJson: `{}`,
+			expected: &testNestedShapesParams{},
+		},
+		{
+			name: "null shapes are left empty",
+			paramsJson: `{"pointer": null, "list": null, "byName": null}`,
+			expected: &testNestedShapesParams{},
+		},
+		{
+			name: "defaults are applied inside each shape",
+			paramsJson: `{"pointer": {"host": "a"}, "list": [{"host": "b"}, {"host": "c", "port": 1}], "byName": {"d": {"host": "d"}}}`,
+			expected: &testNestedShapesParams{
+				Pointer: &testDatabaseParams{Host: "a", Port: 5432},
+				List:    []testDatabaseParams{{Host: "b", Port: 5432}, {Host: "c", Port: 1}},
+				ByName:  map[string]testDatabaseParams{"d": {Host: "d", Port: 5432}},
+			},
+		},
+		{
+			name: "empty list and map are kept",
+			paramsJson: `{"list": [], "byName": {}}`,
+			expected: &testNestedShapesParams{
+				List:   []testDatabaseParams{},
+				ByName: map[string]testDatabaseParams{},
+			},
+		},
+		{
+			name: "pointer to struct is validated",
+			paramsJson: `{"pointer": {"bogus": 1}}`,
+			expectedProblemSubstrings: []string{
+				"2 problems were found",
+				"pointer.host: is required, but wasn't provided",
+				"pointer.bogus: isn't a known param",
+			},
+		},
+		{
+			name: "list of structs is validated",
+			paramsJson: `{"list": [{"host": "a"}, {"bogus": 2}]}`,
+			expectedProblemSubstrings: []string{
+				"2 problems were found",
+				"list[1].host: is required, but wasn't provided",
+				"list[1].bogus: isn't a known param",
+			},
+		},
+		{
+			name: "map of structs is validated",
+			paramsJson: `{"byName": {"a": {"host": "a"}, "b": {"bogus": 3}}}`,
+			expectedProblemSubstrings: []string{
+				"2 problems were found",
+				"byName.b.host: is required, but wasn't provided",
+				"byName.b.bogus: isn't a known param",
+			},
+		},
+		{
+			name: "wrong JSON types for each shape",
+			paramsJson: `{"pointer": [], "list": {}, "byName": []}`,
+			expectedProblemSubstrings: []string{
+				"3 problems were found",
+				"pointer: expected a JSON object",
+				"list: expected a JSON array",
+				"byName: expected a JSON object",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ParseParams[testNestedShapesParams](testCase.paramsJson)
+			if len(testCase.expectedProblemSubstrings) == 0 {
+				if err != nil {
+					t.Fatalf("Expected no error, but got: %v", err)
+				}
+				if !reflect.DeepEqual(result, testCase.expected) {
+					t.Fatalf("Expected params %+v, but got %+v", testCase.expected, result)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error, but got params %+v", result)
+			}
+			for _, substring := range testCase.expectedProblemSubstrings {
+				if !strings.Contains(err.Error(), substring) {
+					t.Errorf("Expected the error to contain '%v', but got: %v", substring, err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseParamsRejectsBadlyTaggedStructs(t *testing.T) {
+	type requiredWithDefault struct {
+		Value string `required:"true" default:"x"`
+	}
+	type badDefault struct {
+		Value int `default:"notanint"`
+	}
+	type badEnumValue struct {
+		Value int `enum:"1,two"`
+	}
+	type patternOnNonString struct {
+		Value int `pattern:"^[0-9]+$"`
+	}
+	type badPattern struct {
+		Value string `pattern:"("`
+	}
+	type badRequired struct {
+		Value string `required:"yes"`
+	}
+	type arrayOfStructs struct {
+		Value [2]testDatabaseParams
+	}
+	type nonStringMapKeys struct {
+		Value map[int]string
+	}
+
+	testCases := []struct {
+		name string
+		parseFunc func() error
+	}{
+		{name: "required with default", parseFunc: func() error { _, err := ParseParams[requiredWithDefault](`{}`); return err }},
+		{name: "default of the wrong type", parseFunc: func() error { _, err := ParseParams[badDefault](`{}`); return err }},
+		{name: "enum value of the wrong type", parseFunc: func() error { _, err := ParseParams[badEnumValue](`{}`); return err }},
+		{name: "pattern on non-string", parseFunc: func() error { _, err := ParseParams[patternOnNonString](`{}`); return err }},
+		{name: "invalid pattern", parseFunc: func() error { _, err := ParseParams[badPattern](`{}`); return err }},
+		{name: "non-bool required", parseFunc: func() error { _, err := ParseParams[badRequired](`{}`); return err }},
+		{name: "array of structs", parseFunc: func() error { _, err := ParseParams[arrayOfStructs](`{}`); return err }},
+		{name: "map with non-string keys", parseFunc: func() error { _, err := ParseParams[nonStringMapKeys](`{}`); return err }},
+		{name: "non-struct params", parseFunc: func() error { _, err := ParseParams[string](`{}`); return err }},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := testCase.parseFunc(); err == nil {
+				t.Fatalf("Expected an error, but got none")
+			}
+		})
+	}
+}
+
+func TestGetJsonSchema(t *testing.T) {
+	schemaBytes, err := GetJsonSchema[testParams]()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	schema := map[string]interface{}{}
+	if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+		t.Fatalf("Expected the schema to be valid JSON, but got error: %v", err)
+	}
+	properties := schema["properties"].(map[string]interface{})
+	databaseSchema := properties["database"].(map[string]interface{})
+
+	testCases := []struct {
+		name string
+		actual interface{}
+		expected interface{}
+	}{
+		{name: "dialect", actual: schema["$schema"], expected: "http://json-schema.org/draft-07/schema#"},
+		{name: "root type", actual: schema["type"], expected: "object"},
+		{name: "unknown keys disallowed", actual: schema["additionalProperties"], expected: false},
+		{name: "required", actual: schema["required"], expected: []interface{}{"image"}},
+		{name: "ignored and unexported fields omitted", actual: len(properties), expected: 6},
+		{
+			name: "required string",
+			actual: properties["image"],
+			expected: map[string]interface{}{"type": "string", "minLength": float64(1), "description": "The image to run"},
+		},
+		{
+			name: "string enum with default",
+			actual: properties["logLevel"],
+			expected: map[string]interface{}{"type": "string", "default": "info", "enum": []interface{}{"debug", "info", "warn"}},
+		},
+		{
+			name: "integer enum with default",
+			actual: properties["numReplicas"],
+			expected: map[string]interface{}{"type": "integer", "default": float64(1), "enum": []interface{}{float64(1), float64(3), float64(5)}},
+		},
+		{
+			name: "pattern",
+			actual: properties["version"],
+			expected: map[string]interface{}{"type": "string", "pattern": "^v[0-9]+$"},
+		},
+		{
+			name: "array with default",
+			actual: properties["tags"],
+			expected: map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}, "default": []interface{}{"a", "b"}},
+		},
+		{name: "nested struct required", actual: databaseSchema["required"], expected: []interface{}{"host"}},
+		{
+			name: "nested struct property",
+			actual: databaseSchema["properties"].(map[string]interface{})["port"],
+			expected: map[string]interface{}{"type": "integer", "default": float64(5432)},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if !reflect.DeepEqual(testCase.actual, testCase.expected) {
+				t.Fatalf("Expected %#v, but got %#v", testCase.expected, testCase.actual)
+			}
+		})
+	}
+}
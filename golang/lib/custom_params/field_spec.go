@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package custom_params
+
+import (
+	"encoding/json"
+	"github.com/palantir/stacktrace"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+	jsonTagName = "json"
+	requiredTagName = "required"
+	defaultTagName = "default"
+	enumTagName = "enum"
+	patternTagName = "pattern"
+	descriptionTagName = "description"
+
+	enumValueSeparator = ","
+	// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
+
+	jsonTagOptionsSeparator = ","
+	ignoredFieldJsonName = "-"
+)
+
+// The parsed tags of a single field in a params struct
+type fieldSpec struct {
+	fieldIndex int
+	jsonName string
+	isRequired bool
+
+	// Will be nil if the field has no default
+	defaultValueJson json.RawMessage
+
+	// The enum values, parsed into the field's type; will be empty if the field isn't an enum
+	enumValues []interface{}
+
+	// Will be nil if the field has no pattern
+	pattern *regexp.Regexp
+
+	description string
+}
+
+// NOTE: Errors returned here indicate badly-tagged structs (i.e. bugs in the testsuite) rather than bad params
+func getFieldSpecs(structType reflect.Type) ([]*fieldSpec, error) {
+	result := []*fieldSpec{}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.PkgPath != "" {
+			// Unexported fields are invisible to the JSON decoder too
+			continue
+		}
+		if field.Anonymous {
+			return nil, stacktrace.NewError("Field '%v' is embedded, but embedded fields aren't supported in params structs", field.Name)
+		}
+		if err := validateFieldType(field.Type); err != nil {
+			return nil, stacktrace.Propagate(err, "Field '%v' has a type that isn't supported in params structs", field.Name)
+		}
+		spec, err := getFieldSpec(i, field)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred parsing the tags of field '%v'", field.Name)
+		}
+		if spec == nil {
+			continue
+		}
+		result = append(result, spec)
+	}
+	return result, nil
+}
+
+// Returns nil if the field is ignored by the JSON decoder
+func getFieldSpec(fieldIndex int, field reflect.StructField) (*fieldSpec, error) {
+	jsonName := field.Name
+	if jsonTag, found := field.Tag.Lookup(jsonTagName); found {
+		tagName := strings.Split(jsonTag, jsonTagOptionsSeparator)[0]
+		if tagName == ignoredFieldJsonName {
+			return nil, nil
+		}
+		if tagName != "" {
+			jsonName = tagName
+		}
+	}
+
+	isRequired := false
+	if requiredStr, found := field.Tag.Lookup(requiredTagName); found {
+		parsedRequired, err := strconv.ParseBool(requiredStr)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred parsing required tag value '%v' as a bool", requiredStr)
+		}
+		isRequired = parsedRequired
+	}
+
+	var defaultValueJson json.RawMessage = nil
+	if defaultStr, found := field.Tag.Lookup(defaultTagName); found {
+		if isRequired {
+			return nil, stacktrace.NewError("The field is both required and has a default, which is contradictory")
+		}
+		valueJson, err := convertTagValueToJson(defaultStr, field.Type)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred parsing default value '%v'", defaultStr)
+		}
+		defaultValueJson = valueJson
+	}
+
+	enumValues := []interface{}{}
+	if enumStr, found := field.Tag.Lookup(enumTagName); found {
+		for _, enumValueStr := range strings.Split(enumStr, enumValueSeparator) {
+			enumValue, err := parseTagValue(enumValueStr, field.Type)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred parsing enum value '%v'", enumValueStr)
+			}
+			enumValues = append(enumValues, enumValue)
+		}
+	}
+
+	var pattern *regexp.Regexp = nil
+	if patternStr, found := field.Tag.Lookup(patternTagName); found {
+		if field.Type.Kind() != reflect.String {
+			return nil, stacktrace.NewError("A pattern was declared, but patterns are only supported on string fields")
+		}
+		compiledPattern, err := regexp.Compile(patternStr)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred compiling pattern '%v'", patternStr)
+		}
+		pattern = compiledPattern
+	}
+
+	return &fieldSpec{
+		fieldIndex:       fieldIndex,
+		jsonName:         jsonName,
+		isRequired:       isRequired,
+		defaultValueJson: defaultValueJson,
+		enumValues:       enumValues,
+		pattern:          pattern,
+		description:      field.Tag.Get(descriptionTagName),
+	}, nil
+}
+
+// Rejects the types that ParseParams and GetJsonSchema can't both handle the same way
+func validateFieldType(fieldType reflect.Type) error {
+	switch fieldType.Kind() {
+	case reflect.Ptr, reflect.Slice:
+		return validateFieldType(fieldType.Elem())
+	case reflect.Array:
+		// A fixed-size array can be given fewer JSON elements than its size, which would leave structs that never got
+		//  their defaults applied or their required fields checked
+		if typeContainsStruct(fieldType.Elem()) {
+			return stacktrace.NewError("Array type '%v' contains structs; use a slice instead", fieldType)
+		}
+		return validateFieldType(fieldType.Elem())
+	case reflect.Map:
+		if fieldType.Key().Kind() != reflect.String {
+			return stacktrace.NewError("Map type '%v' doesn't have string keys, so can't be represented in JSON", fieldType)
+		}
+		return validateFieldType(fieldType.Elem())
+	default:
+		// Nested structs are checked when their own field specs are gotten
+		return nil
+	}
+}
+
+func typeContainsStruct(valueType reflect.Type) bool {
+	switch valueType.Kind() {
+	case reflect.Struct:
+		return true
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeContainsStruct(valueType.Elem())
+	default:
+		return false
+	}
+}
+
+// Tag values for string fields are written bare (e.g. `default:"foo"`) while tag values for all other fields are
+//  written as JSON (e.g. `default:"3"` or `default:"[\"a\", \"b\"]"`)
+func convertTagValueToJson(tagValue string, fieldType reflect.Type) (json.RawMessage, error) {
+	var valueJson json.RawMessage = []byte(tagValue)
+	if fieldType.Kind() == reflect.String {
+		marshalled, err := json.Marshal(tagValue)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred serializing string tag value '%v' to JSON", tagValue)
+		}
+		valueJson = marshalled
+	}
+
+	// We parse the value now so that a bad value is reported as a testsuite bug rather than a bad param
+	if _, err := decodeJsonIntoType(valueJson, fieldType); err != nil {
+		return nil, stacktrace.Propagate(err, "Tag value '%v' isn't valid for a field of type '%v'", tagValue, fieldType)
+	}
+	return valueJson, nil
+}
+
+func parseTagValue(tagValue string, fieldType reflect.Type) (interface{}, error) {
+	valueJson, err := convertTagValueToJson(tagValue, fieldType)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred converting tag value '%v' to JSON", tagValue)
+	}
+	value, err := decodeJsonIntoType(valueJson, fieldType)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred decoding tag value '%v'", tagValue)
+	}
+	return value, nil
+}
+
+func decodeJsonIntoType(valueJson json.RawMessage, valueType reflect.Type) (interface{}, error) {
+	valuePtr := reflect.New(valueType)
+	if err := json.Unmarshal(valueJson, valuePtr.Interface()); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred decoding JSON '%v' into type '%v'", string(valueJson), valueType)
+	}
+	return valuePtr.Elem().Interface(), nil
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package custom_params
+
+import (
+	"encoding/json"
+	"github.com/palantir/stacktrace"
+	"reflect"
+)
+
+const (
+	jsonSchemaDialectUrl = "http://json-schema.org/draft-07/schema#"
+
+	// A required string must be non-empty, to match the check that ParseParams does
+	requiredStringMinLength = 1
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func GetJsonSchema[P any]() ([]byte, error) {
+	paramsType := reflect.TypeOf(new(P)).Elem()
+	if paramsType.Kind() != reflect.Struct {
+		return nil, stacktrace.NewError("Params type must be a struct, but was '%v'", paramsType)
+	}
+	schema, err := getTypeSchema(paramsType)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the JSON schema for type '%v'", paramsType)
+	}
+	schema["$schema"] = jsonSchemaDialectUrl
+
+	result, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing the JSON schema for type '%v'", paramsType)
+	}
+	return result, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func getTypeSchema(valueType reflect.Type) (map[string]interface{}, error) {
+	switch valueType.Kind() {
+	case reflect.Struct:
+		return getStructSchema(valueType)
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Slice, reflect.Array:
+		itemsSchema, err := getTypeSchema(valueType.Elem())
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting the schema for the items of type '%v'", valueType)
+		}
+		return map[string]interface{}{"type": "array", "items": itemsSchema}, nil
+	case reflect.Map:
+		if valueType.Key().Kind() != reflect.String {
+			return nil, stacktrace.NewError("Map type '%v' doesn't have string keys, so can't be represented in JSON", valueType)
+		}
+		valuesSchema, err := getTypeSchema(valueType.Elem())
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting the schema for the values of type '%v'", valueType)
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": valuesSchema}, nil
+	case reflect.Ptr:
+		return getTypeSchema(valueType.Elem())
+	case reflect.Interface:
+		// Any JSON value is allowed
+		return map[string]interface{}{}, nil
+	default:
+		return nil, stacktrace.NewError("Type '%v' can't be represented in a JSON schema", valueType)
+	}
+}
+
+func getStructSchema(structType reflect.Type) (map[string]interface{}, error) {
+	specs, err := getFieldSpecs(structType)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the field specs for type '%v'", structType)
+	}
+
+	properties := map[string]interface{}{}
+	requiredJsonNames := []string{}
+	for _, spec := range specs {
+		fieldType := structType.Field(spec.fieldIndex).Type
+		fieldSchema, err := getTypeSchema(fieldType)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting the schema for field '%v'", spec.jsonName)
+		}
+
+		if spec.isRequired {
+			requiredJsonNames = append(requiredJsonNames, spec.jsonName)
+			if fieldType.Kind() == reflect.String {
+				fieldSchema["minLength"] = requiredStringMinLength
+			}
+		}
+		if spec.defaultValueJson != nil {
+			fieldSchema["default"] = spec.defaultValueJson
+		}
+		if len(spec.enumValues) > 0 {
+			fieldSchema["enum"] = spec.enumValues
+		}
+		if spec.pattern != nil {
+			fieldSchema["pattern"] = spec.pattern.String()
+		}
+		if spec.description != "" {
+			fieldSchema["description"] = spec.description
+		}
+		properties[spec.jsonName] = fieldSchema
+	}
+
+	result := map[string]interface{}{
+		"type": "object",
+		"properties": properties,
+		"additionalProperties": false,
+	}
+	if len(requiredJsonNames) > 0 {
+		result["required"] = requiredJsonNames
+	}
+	return result, nil
+}
@@ -0,0 +1,239 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package custom_params
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/palantir/stacktrace"
+	"gopkg.in/yaml.v3"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+	EnvVarParamPrefix = "SUITE_PARAM_"
+
+	// Used in env var names to set a key inside a nested object, e.g. SUITE_PARAM_database__port
+	// NOTE: This means keys that themselves contain a double underscore can't be set via env vars
+	envVarNestedKeySeparator = "__"
+
+	// Env var values with this prefix are parsed as JSON (e.g. SUITE_PARAM_port=json:5432); all others are strings
+	envVarJsonValuePrefix = "json:"
+
+	redactedParamValue = "<redacted>"
+	// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
+
+	envVarKeyValueSeparator = "="
+	emptyParamsJson = "{}"
+)
+
+// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+// Params whose key contains any of these (case-insensitively) will have their values redacted
+// NOTE: Only keys are matched, so secrets under other keys (e.g. in a list of connection strings) are still logged
+var sensitiveParamKeySubstrings = []string{
+	"password",
+	"secret",
+	"token",
+	"apikey",
+	"api_key",
+	"credential",
+	"privatekey",
+	"private_key",
+}
+// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
+
+// YAML integers are decoded as Go ints, so like JSON numbers they don't lose precision by passing through float64
+var paramsFileExtensionDecoders = map[string]func([]byte, interface{}) error{
+	".json": unmarshalJsonPreservingNumbers,
+	".yaml": yaml.Unmarshal,
+	".yml": yaml.Unmarshal,
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func ResolveParamsJson(paramsFilepath string, paramsJsonStr string, environment []string) (string, error) {
+	result := map[string]interface{}{}
+
+	if paramsFilepath != "" {
+		fileParams, err := readParamsFile(paramsFilepath)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "An error occurred reading params file '%v'", paramsFilepath)
+		}
+		mergeParams(result, fileParams)
+	}
+
+	if strings.TrimSpace(paramsJsonStr) != "" {
+		jsonParams := map[string]interface{}{}
+		if err := unmarshalJsonPreservingNumbers([]byte(paramsJsonStr), &jsonParams); err != nil {
+			return "", stacktrace.Propagate(err, "An error occurred deserializing the params JSON string")
+		}
+		mergeParams(result, jsonParams)
+	}
+
+	envVarParams, err := getEnvVarParams(environment)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the params from the environment variables")
+	}
+	mergeParams(result, envVarParams)
+
+	resultBytes, err := json.Marshal(result)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred serializing the resolved params")
+	}
+	return string(resultBytes), nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func RedactParamsJson(paramsJsonStr string) (string, error) {
+	if strings.TrimSpace(paramsJsonStr) == "" {
+		paramsJsonStr = emptyParamsJson
+	}
+	var params interface{}
+	if err := unmarshalJsonPreservingNumbers([]byte(paramsJsonStr), &params); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred deserializing the params JSON string")
+	}
+	// The result is only for humans to read, so we don't want the redaction marker escaped to '\u003credacted\u003e'
+	resultBuffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(resultBuffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(redactParams(params)); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred serializing the redacted params")
+	}
+	return strings.TrimSpace(resultBuffer.String()), nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func readParamsFile(paramsFilepath string) (map[string]interface{}, error) {
+	extension := strings.ToLower(path.Ext(paramsFilepath))
+	decoder, found := paramsFileExtensionDecoders[extension]
+	if !found {
+		allowedExtensions := []string{}
+		for allowedExtension := range paramsFileExtensionDecoders {
+			allowedExtensions = append(allowedExtensions, allowedExtension)
+		}
+		sort.Strings(allowedExtensions)
+		return nil, stacktrace.NewError(
+			"Params file '%v' has unrecognized extension '%v'; allowed extensions are %v",
+			paramsFilepath,
+			extension,
+			allowedExtensions,
+		)
+	}
+
+	fileBytes, err := ioutil.ReadFile(paramsFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the contents of params file '%v'", paramsFilepath)
+	}
+	result := map[string]interface{}{}
+	if err := decoder(fileBytes, &result); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred deserializing the contents of params file '%v'", paramsFilepath)
+	}
+	return result, nil
+}
+
+// Env var values are strings unless explicitly marked as JSON, because guessing (e.g. that '1.0' is meant to be a
+//  number) would make string params that happen to look like numbers, bools or null impossible to set
+func getEnvVarParams(environment []string) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+	for _, envVar := range environment {
+		if !strings.HasPrefix(envVar, EnvVarParamPrefix) {
+			continue
+		}
+		keyValue := strings.SplitN(strings.TrimPrefix(envVar, EnvVarParamPrefix), envVarKeyValueSeparator, 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			continue
+		}
+		keyPath := strings.Split(keyValue[0], envVarNestedKeySeparator)
+		valueStr := keyValue[1]
+
+		var value interface{} = valueStr
+		if strings.HasPrefix(valueStr, envVarJsonValuePrefix) {
+			if err := unmarshalJsonPreservingNumbers([]byte(strings.TrimPrefix(valueStr, envVarJsonValuePrefix)), &value); err != nil {
+				return nil, stacktrace.Propagate(
+					err,
+					"An error occurred parsing the value of env var '%v%v' as JSON",
+					EnvVarParamPrefix,
+					keyValue[0],
+				)
+			}
+		}
+
+		// Build the nested object from the innermost key outwards, so it can be merged like any other layer
+		var layer interface{} = value
+		for i := len(keyPath) - 1; i >= 0; i-- {
+			layer = map[string]interface{}{keyPath[i]: layer}
+		}
+		mergeParams(result, layer.(map[string]interface{}))
+	}
+	return result, nil
+}
+
+// Like json.Unmarshal, except numbers are decoded as json.Number rather than float64 so that integers above 2^53
+//  survive being re-serialized
+func unmarshalJsonPreservingNumbers(jsonBytes []byte, destination interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+	if err := decoder.Decode(destination); err != nil {
+		return stacktrace.Propagate(err, "An error occurred decoding the JSON")
+	}
+	// The decoder stops after the first value, whereas json.Unmarshal rejects anything after it
+	if _, err := decoder.Token(); err != io.EOF {
+		return stacktrace.NewError("Found unexpected data after the end of the JSON value")
+	}
+	return nil
+}
+
+// Objects are merged key-by-key; any other value in the overrides replaces the one in the base
+func mergeParams(base map[string]interface{}, overrides map[string]interface{}) {
+	for key, overrideValue := range overrides {
+		overrideMap, isOverrideMap := overrideValue.(map[string]interface{})
+		baseMap, isBaseMap := base[key].(map[string]interface{})
+		if isOverrideMap && isBaseMap {
+			mergeParams(baseMap, overrideMap)
+			continue
+		}
+		base[key] = overrideValue
+	}
+}
+
+func redactParams(params interface{}) interface{} {
+	switch typedParams := params.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for key, value := range typedParams {
+			if isSensitiveParamKey(key) {
+				result[key] = redactedParamValue
+				continue
+			}
+			result[key] = redactParams(value)
+		}
+		return result
+	case []interface{}:
+		result := []interface{}{}
+		for _, value := range typedParams {
+			result = append(result, redactParams(value))
+		}
+		return result
+	default:
+		return params
+	}
+}
+
+func isSensitiveParamKey(key string) bool {
+	lowercaseKey := strings.ToLower(key)
+	for _, sensitiveSubstring := range sensitiveParamKeySubstrings {
+		if strings.Contains(lowercaseKey, sensitiveSubstring) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package custom_params
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestResolveParamsJson(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "params-layers-test")
+	if err != nil {
+		t.Fatalf("An error occurred creating a temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	jsonParamsFilepath := path.Join(tempDirpath, "params.json")
+	jsonParamsFileContents := `{"image": "from-json-file", "database": {"host": "file-host", "port": 1}, "tags": ["file"]}`
+	if err := ioutil.WriteFile(jsonParamsFilepath, []byte(jsonParamsFileContents), 0644); err != nil {
+		t.Fatalf("An error occurred writing the JSON params file: %v", err)
+	}
+	yamlParamsFilepath := path.Join(tempDirpath, "params.yml")
+	yamlParamsFileContents := "image: from-yaml-file\ndatabase:\n  host: yaml-host\n"
+	if err := ioutil.WriteFile(yamlParamsFilepath, []byte(yamlParamsFileContents), 0644); err != nil {
+		t.Fatalf("An error occurred writing the YAML params file: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		paramsFilepath string
+		paramsJson string
+		environment []string
+		expected map[string]interface{}
+	}{
+		{
+			name: "no layers",
+			expected: map[string]interface{}{},
+		},
+		{
+			name: "JSON file only",
+			paramsFilepath: jsonParamsFilepath,
+			expected: map[string]interface{}{
+				"image": "from-json-file",
+				"database": map[string]interface{}{"host": "file-host", "port": float64(1)},
+				"tags": []interface{}{"file"},
+			},
+		},
+		{
+			name: "YAML file only",
+			paramsFilepath: yamlParamsFilepath,
+			expected: map[string]interface{}{
+				"image": "from-yaml-file",
+				"database": map[string]interface{}{"host": "yaml-host"},
+			},
+		},
+		{
+			name: "params JSON overrides file key-by-key",
+			paramsFilepath: jsonParamsFilepath,
+			paramsJson: `{"database": {"port": 2}, "tags": ["json"]}`,
+			expected: map[string]interface{}{
+				"image": "from-json-file",
+				"database": map[string]interface{}{"host": "file-host", "port": float64(2)},
+				"tags": []interface{}{"json"},
+			},
+		},
+		{
+			name: "env vars override params JSON and file",
+			paramsFilepath: jsonParamsFilepath,
+			paramsJson: `{"image": "from-json", "database": {"port": 2}}`,
+			environment: []string{
+				"SUITE_PARAM_image=from-env",
+				"SUITE_PARAM_database__port=json:3",
+				"UNRELATED=ignored",
+			},
+			expected: map[string]interface{}{
+				"image": "from-env",
+				"database": map[string]interface{}{"host": "file-host", "port": float64(3)},
+				"tags": []interface{}{"file"},
+			},
+		},
+		{
+			name: "env var values are strings unless marked as JSON",
+			environment: []string{
+				"SUITE_PARAM_version=1.0",
+				"SUITE_PARAM_count=2",
+				"SUITE_PARAM_flag=true",
+				"SUITE_PARAM_nothing=null",
+				"SUITE_PARAM_list=json:[1, \"a\"]",
+				"SUITE_PARAM_withEquals=a=b",
+			},
+			expected: map[string]interface{}{
+				"version": "1.0",
+				"count": "2",
+				"flag": "true",
+				"nothing": "null",
+				"list": []interface{}{float64(1), "a"},
+				"withEquals": "a=b",
+			},
+		},
+		{
+			name: "scalar env var replaces object",
+			paramsJson: `{"database": {"host": "json-host"}}`,
+			environment: []string{"SUITE_PARAM_database=plain"},
+			expected: map[string]interface{}{
+				"database": "plain",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			resultJson, err := ResolveParamsJson(testCase.paramsFilepath, testCase.paramsJson, testCase.environment)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			result := map[string]interface{}{}
+			if err := json.Unmarshal([]byte(resultJson), &result); err != nil {
+				t.Fatalf("Expected the resolved params to be valid JSON, but got error: %v", err)
+			}
+			if !reflect.DeepEqual(result, testCase.expected) {
+				t.Fatalf("Expected resolved params %v, but got %v", testCase.expected, result)
+			}
+		})
+	}
+}
+
+// 2^53 + 1 is the smallest integer that float64 can't represent
+func TestResolveParamsJsonPreservesLargeIntegers(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "params-layers-test")
+	if err != nil {
+		t.Fatalf("An error occurred creating a temp directory: %v", err)
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	jsonParamsFilepath := path.Join(tempDirpath, "params.json")
+	if err := ioutil.WriteFile(jsonParamsFilepath, []byte(`{"seed": 9007199254740993}`), 0644); err != nil {
+		t.Fatalf("An error occurred writing the JSON params file: %v", err)
+	}
+	yamlParamsFilepath := path.Join(tempDirpath, "params.yaml")
+	if err := ioutil.WriteFile(yamlParamsFilepath, []byte("seed: 9007199254740993\n"), 0644); err != nil {
+		t.Fatalf("An error occurred writing the YAML params file: %v", err)
+	}
+
+	testCases := []struct {
+		name string
+		paramsFilepath string
+		paramsJson string
+		environment []string
+	}{
+		{name: "JSON file", paramsFilepath: jsonParamsFilepath},
+		{name: "YAML file", paramsFilepath: yamlParamsFilepath},
+		{name: "params JSON", paramsJson: `{"seed": 9007199254740993}`},
+		{name: "marked env var", environment: []string{"SUITE_PARAM_seed=json:9007199254740993"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := ResolveParamsJson(testCase.paramsFilepath, testCase.paramsJson, testCase.environment)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			expected := `{"seed":9007199254740993}`
+			if result != expected {
+				t.Fatalf("Expected resolved params '%v', but got '%v'", expected, result)
+			}
+		})
+	}
+}
+
+func TestResolveParamsJsonErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		paramsFilepath string
+		paramsJson string
+		environment []string
+	}{
+		{name: "unrecognized params file extension", paramsFilepath: "/params.txt"},
+		{name: "nonexistent params file", paramsFilepath: "/nonexistent/params.json"},
+		{name: "invalid params JSON", paramsJson: `{"image": `},
+		{name: "data after the params JSON", paramsJson: `{"image": "a"} {"image": "b"}`},
+		{name: "invalid marked env var JSON", environment: []string{"SUITE_PARAM_list=json:[1,"}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := ResolveParamsJson(testCase.paramsFilepath, testCase.paramsJson, testCase.environment); err == nil {
+				t.Fatalf("Expected an error, but got none")
+			}
+		})
+	}
+}
+
+func TestMergeParams(t *testing.T) {
+	testCases := []struct {
+		name string
+		base map[string]interface{}
+		overrides map[string]interface{}
+		expected map[string]interface{}
+	}{
+		{
+			name: "disjoint keys are combined",
+			base: map[string]interface{}{"a": 1},
+			overrides: map[string]interface{}{"b": 2},
+			expected: map[string]interface{}{"a": 1, "b": 2},
+		},
+		{
+			name: "nested objects are merged recursively",
+			base: map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": 1, "d": 2}}},
+			overrides: map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"d": 3}}},
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": 1, "d": 3}}},
+		},
+		{
+			name: "arrays are replaced rather than appended",
+			base: map[string]interface{}{"a": []interface{}{1, 2}},
+			overrides: map[string]interface{}{"a": []interface{}{3}},
+			expected: map[string]interface{}{"a": []interface{}{3}},
+		},
+		{
+			name: "object replaces scalar",
+			base: map[string]interface{}{"a": 1},
+			overrides: map[string]interface{}{"a": map[string]interface{}{"b": 2}},
+			expected: map[string]interface{}{"a": map[string]interface{}{"b": 2}},
+		},
+		{
+			name: "null override replaces value",
+			base: map[string]interface{}{"a": 1},
+			overrides: map[string]interface{}{"a": nil},
+			expected: map[string]interface{}{"a": nil},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mergeParams(testCase.base, testCase.overrides)
+			if !reflect.DeepEqual(testCase.base, testCase.expected) {
+				t.Fatalf("Expected merged params %v, but got %v", testCase.expected, testCase.base)
+			}
+		})
+	}
+}
+
+func TestRedactParamsJson(t *testing.T) {
+	testCases := []struct {
+		name string
+		paramsJson string
+		expected string
+	}{
+		{
+			name: "empty params",
+			paramsJson: "",
+			expected: `{}`,
+		},
+		{
+			name: "non-sensitive keys are kept",
+			paramsJson: `{"image": "nginx", "port": 80}`,
+			expected: `{"image":"nginx","port":80}`,
+		},
+		{
+			name: "sensitive keys are matched case-insensitively by substring",
+			paramsJson: `{"dbPassword": "hunter2", "API_KEY": "abc", "authToken": 123, "user": "admin"}`,
+			expected: `{"API_KEY":"<redacted>","authToken":"<redacted>","dbPassword":"<redacted>","user":"admin"}`,
+		},
+		{
+			name: "whole objects under sensitive keys are redacted",
+			paramsJson: `{"credentials": {"user": "admin", "pass": "x"}}`,
+			expected: `{"credentials":"<redacted>"}`,
+		},
+		{
+			name: "nested objects and arrays are redacted",
+			paramsJson: `{"database": {"host": "db", "secretKey": "x"}, "replicas": [{"name": "a", "privateKey": "y"}]}`,
+			expected: `{"database":{"host":"db","secretKey":"<redacted>"},"replicas":[{"name":"a","privateKey":"<redacted>"}]}`,
+		},
+		{
+			name: "large integers are kept exactly",
+			paramsJson: `{"seed": 9007199254740993, "apiToken": 9007199254740993}`,
+			expected: `{"apiToken":"<redacted>","seed":9007199254740993}`,
+		},
+		{
+			name: "secrets under non-matching keys aren't redacted",
+			paramsJson: `{"dsn": "postgres://admin:hunter2@db", "args": ["--password=hunter2"]}`,
+			expected: `{"args":["--password=hunter2"],"dsn":"postgres://admin:hunter2@db"}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := RedactParamsJson(testCase.paramsJson)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if result != testCase.expected {
+				t.Fatalf("Expected redacted params '%v', but got '%v'", testCase.expected, result)
+			}
+		})
+	}
+}
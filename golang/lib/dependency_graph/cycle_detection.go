@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package dependency_graph
+
+import (
+	"sort"
+)
+
+/*
+Finds a dependency cycle in a graph of node name -> names of the nodes it depends on, returning the path of the cycle
+	(starting and ending with the same node, e.g. [a b a]) or nil if the graph has no cycles
+
+Dependencies that aren't themselves keys of the graph are treated as having no dependencies of their own. Nodes and
+	dependencies are visited in sorted order, so that the same graph always reports the same cycle.
+*/
+func FindCycle(graph map[string]map[string]bool) []string {
+	// Depth-first search, where finding a node that's still on the current path means we've found a cycle
+	const (
+		unvisited = iota
+		onCurrentPath
+		visited
+	)
+	visitStates := map[string]int{}
+	var visit func(nodeName string, path []string) []string
+	visit = func(nodeName string, path []string) []string {
+		path = append(path, nodeName)
+		switch visitStates[nodeName] {
+		case visited:
+			return nil
+		case onCurrentPath:
+			// The path may have started outside the cycle, so we trim it to start where the cycle does
+			for i, pathNodeName := range path {
+				if pathNodeName == nodeName {
+					return path[i:]
+				}
+			}
+		}
+		visitStates[nodeName] = onCurrentPath
+		for _, dependencyName := range getSortedKeys(graph[nodeName]) {
+			if cycle := visit(dependencyName, path); cycle != nil {
+				return cycle
+			}
+		}
+		visitStates[nodeName] = visited
+		return nil
+	}
+
+	for _, nodeName := range getSortedKeys(graph) {
+		if cycle := visit(nodeName, []string{}); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func getSortedKeys[V any](set map[string]V) []string {
+	result := []string{}
+	for key := range set {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,57 @@
+package execution
+
+import (
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+/*
+Configures how long the testsuite takes to shut down after it receives a termination signal
+
+The whole shutdown is bounded by the shutdown timeout, which should be less than the testsuite container's stop timeout
+	(10 seconds by default in Docker) so that the container isn't killed before it's done. The timeout is split up as:
+ - An interrupted test gets the interrupted test grace period to return from its run; if it doesn't, its teardown is run
+	anyway while the run is still going
+ - The interrupted test's teardown and the stopping of the gRPC server get whatever is left, minus the telemetry flush
+	timeout
+ - The telemetry flush timeout is reserved for flushing the remaining spans and stopping the metrics server
+
+NOTE: The testsuite's AfterAll hook (if any) runs between the gRPC server stopping and the telemetry being flushed, and
+	isn't bounded by the shutdown timeout
+*/
+type ShutdownConfig struct {
+	timeout time.Duration
+	interruptedTestGracePeriod time.Duration
+	telemetryFlushTimeout time.Duration
+}
+
+func NewShutdownConfig(timeout time.Duration, interruptedTestGracePeriod time.Duration, telemetryFlushTimeout time.Duration) *ShutdownConfig {
+	return &ShutdownConfig{timeout: timeout, interruptedTestGracePeriod: interruptedTestGracePeriod, telemetryFlushTimeout: telemetryFlushTimeout}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (config ShutdownConfig) validate() error {
+	if config.interruptedTestGracePeriod < 0 {
+		return stacktrace.NewError("The interrupted test grace period must not be negative, but was %v", config.interruptedTestGracePeriod)
+	}
+	if config.telemetryFlushTimeout <= 0 {
+		return stacktrace.NewError("The telemetry flush timeout must be positive, but was %v", config.telemetryFlushTimeout)
+	}
+	// The teardown of an interrupted test needs some time of its own
+	if config.interruptedTestGracePeriod + config.telemetryFlushTimeout >= config.timeout {
+		return stacktrace.NewError(
+			"The shutdown timeout (%v) must be greater than the interrupted test grace period (%v) plus the telemetry flush timeout (%v)",
+			config.timeout,
+			config.interruptedTestGracePeriod,
+			config.telemetryFlushTimeout,
+		)
+	}
+	return nil
+}
+
+// How long the gRPC server gets to stop, which includes the interrupted test's run and teardown finishing
+func (config ShutdownConfig) getServerStopGracePeriod() time.Duration {
+	return config.timeout - config.telemetryFlushTimeout
+}
@@ -5,7 +5,11 @@
 
 package execution
 
-import "github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"io"
+)
 
 // Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 type TestSuiteConfigurator interface {
@@ -15,3 +19,25 @@ type TestSuiteConfigurator interface {
 	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 	ParseParamsAndCreateSuite(paramsJsonStr string) (testsuite.TestSuite, error)
 }
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type ParamsJsonSchemaProvider interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	GetParamsJsonSchema() ([]byte, error)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func PrintParamsJsonSchema(configurator TestSuiteConfigurator, output io.Writer) error {
+	schemaProvider, ok := configurator.(ParamsJsonSchemaProvider)
+	if !ok {
+		return stacktrace.NewError("The testsuite configurator doesn't implement ParamsJsonSchemaProvider, so has no params JSON schema")
+	}
+	schema, err := schemaProvider.GetParamsJsonSchema()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the params JSON schema from the testsuite configurator")
+	}
+	if _, err := output.Write(append(schema, '\n')); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the params JSON schema")
+	}
+	return nil
+}
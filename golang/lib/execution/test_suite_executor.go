@@ -1,44 +1,170 @@
 package execution
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/core_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/custom_params"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/metrics"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/rpc_api_consts"
-	"github.com/kurtosis-tech/minimal-grpc-server/server"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/rpc_security"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/tracing"
 	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"os"
+	"strings"
 	"time"
 )
 
 const (
-	grpcServerStopGracePeriod = 5 * time.Second
+	// The fully-qualified name of the testsuite service, as the gRPC health service expects it
+	testSuiteServiceName = "test_suite_api.TestSuiteService"
+
+	// The gRPC health service reports the status of the server as a whole under the empty service name
+	overallHealthServiceName = ""
+
+	beforeAllSpanName = "BeforeAll"
+	afterAllSpanName = "AfterAll"
+
+	unixSocketListenAddressPrefix = "unix://"
+	unixSocketListenProtocol = "unix"
 )
 
+// Returned (wrapped) by TestSuiteExecutor.Run when the testsuite was shut down while a test was running, so that
+//  callers can use stacktrace.RootCause to distinguish it from other failures
+var ErrTestInterrupted = errors.New("the testsuite was shut down while a test was running")
+
 type TestSuiteExecutor struct {
 	kurtosisApiSocket string  // Can be empty if the testsuite is in metadata-providing mode
 	logLevelStr string
+	logFormatStr string  // Can be empty, in which case the log format set by the configurator is kept
+	paramsFilepath string  // Can be empty if no params file was provided
 	paramsJsonStr string
 	configurator TestSuiteConfigurator
+	serverSecurityConfig *rpc_security.ServerSecurityConfig
+	apiContainerSecurityConfig *rpc_security.ApiContainerSecurityConfig
+	tracingConfig *tracing.TracingConfig
+
+	// Either an 'address:port' for TCP (where port 0 means "pick a free port"), or 'unix://' followed by a socket filepath
+	listenAddress string
+
+	// Can be empty if the listen address doesn't need to be written anywhere
+	listenAddressOutputFilepath string
+
+	// An 'address:port' to serve Prometheus metrics on; can be empty if metrics shouldn't be served
+	metricsListenAddress string
+
+	shutdownConfig *ShutdownConfig
 }
 
-func NewTestSuiteExecutor(kurtosisApiSocket string, logLevelStr string, paramsJsonStr string, configurator TestSuiteConfigurator) *TestSuiteExecutor {
-	return &TestSuiteExecutor{kurtosisApiSocket: kurtosisApiSocket, logLevelStr: logLevelStr, paramsJsonStr: paramsJsonStr, configurator: configurator}
+func NewTestSuiteExecutor(
+		kurtosisApiSocket string,
+		logLevelStr string,
+		logFormatStr string,
+		paramsFilepath string,
+		paramsJsonStr string,
+		configurator TestSuiteConfigurator,
+		serverSecurityConfig *rpc_security.ServerSecurityConfig,
+		apiContainerSecurityConfig *rpc_security.ApiContainerSecurityConfig,
+		tracingConfig *tracing.TracingConfig,
+		listenAddress string,
+		listenAddressOutputFilepath string,
+		metricsListenAddress string,
+		shutdownConfig *ShutdownConfig) *TestSuiteExecutor {
+	return &TestSuiteExecutor{
+		kurtosisApiSocket: kurtosisApiSocket,
+		logLevelStr: logLevelStr,
+		logFormatStr: logFormatStr,
+		paramsFilepath: paramsFilepath,
+		paramsJsonStr: paramsJsonStr,
+		configurator: configurator,
+		serverSecurityConfig: serverSecurityConfig,
+		apiContainerSecurityConfig: apiContainerSecurityConfig,
+		tracingConfig: tracingConfig,
+		listenAddress: listenAddress,
+		listenAddressOutputFilepath: listenAddressOutputFilepath,
+		metricsListenAddress: metricsListenAddress,
+		shutdownConfig: shutdownConfig,
+	}
 }
 
 func (executor TestSuiteExecutor) Run() error {
 	if err := executor.configurator.SetLogLevel(executor.logLevelStr); err != nil {
 		return stacktrace.Propagate(err, "An error occurred setting the loglevel before running the testsuite")
 	}
+	if executor.logFormatStr != "" {
+		if err := logging.SetLogFormat(logrus.StandardLogger(), logging.LogFormat(executor.logFormatStr)); err != nil {
+			return stacktrace.Propagate(err, "An error occurred setting the log format before running the testsuite")
+		}
+	}
+	logging.AddContextFieldsHook(logrus.StandardLogger())
+
+	if err := executor.shutdownConfig.validate(); err != nil {
+		return stacktrace.Propagate(err, "The shutdown config is invalid")
+	}
 
-	suite, err := executor.configurator.ParseParamsAndCreateSuite(executor.paramsJsonStr)
+	shutdownTracingFunc, err := executor.tracingConfig.InitTracing()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred initializing tracing")
+	}
+	var metricsServer *metrics.MetricsServer = nil
+	defer func() {
+		// The spans and the metrics share a single timeout, so that together they stay within the shutdown timeout
+		ctx, cancelFunc := context.WithTimeout(context.Background(), executor.shutdownConfig.telemetryFlushTimeout)
+		defer cancelFunc()
+		if metricsServer != nil {
+			if err := metricsServer.Stop(ctx); err != nil {
+				logrus.Warnf("An error occurred stopping the metrics server: %v", err)
+			}
+		}
+		shutdownTracingFunc(ctx)
+	}()
+
+	if executor.metricsListenAddress != "" {
+		metricsServer, err = metrics.StartMetricsServer(executor.metricsListenAddress)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred starting the metrics server on '%v'", executor.metricsListenAddress)
+		}
+	}
+
+	// The layers are merged here, rather than by the configurator, so that every testsuite gets the same precedence
+	resolvedParamsJsonStr, err := custom_params.ResolveParamsJson(executor.paramsFilepath, executor.paramsJsonStr, os.Environ())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred resolving the suite params from the params file, params JSON, and environment variables")
+	}
+	redactedParamsJsonStr, err := custom_params.RedactParamsJson(resolvedParamsJsonStr)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred redacting the resolved suite params for logging")
+	}
+	logrus.Infof("Resolved suite params: %v", redactedParamsJsonStr)
+
+	suite, err := executor.configurator.ParseParamsAndCreateSuite(resolvedParamsJsonStr)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred parsing the suite params JSON and creating the testsuite")
 	}
 
 	var apiContainerService core_api_bindings.ApiContainerServiceClient = nil
 	if executor.kurtosisApiSocket != "" {
-		// TODO SECURITY: Use HTTPS to ensure we're connecting to the real Kurtosis API servers
-		conn, err := grpc.Dial(executor.kurtosisApiSocket, grpc.WithInsecure())
+		dialOptions, err := executor.apiContainerSecurityConfig.GetDialOptions()
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting the options for connecting to the Kurtosis API server")
+		}
+		dialOptions = append(
+			dialOptions,
+			grpc.WithChainUnaryInterceptor(
+				tracing.NewApiContainerClientInterceptor(),
+				metrics.NewApiContainerClientInterceptor(),
+			),
+		)
+		conn, err := grpc.Dial(executor.kurtosisApiSocket, dialOptions...)
 		if err != nil {
 			return stacktrace.Propagate(
 				err,
@@ -49,24 +175,125 @@ func (executor TestSuiteExecutor) Run() error {
 		defer conn.Close()
 
 		apiContainerService = core_api_bindings.NewApiContainerServiceClient(conn)
+
+		// The hooks are only run when the testsuite will actually run tests; when providing metadata, no test will
+		//  reference the fixtures so there's no point paying for them
+		if beforeAllHook, ok := suite.(testsuite.BeforeAllHook); ok {
+			logging.SetTestContext("", logging.BeforeAllTestPhase)
+			logrus.Info("Running the testsuite's BeforeAll hook...")
+			_, span := tracing.StartPhaseSpan(context.Background(), beforeAllSpanName)
+			startTime := time.Now()
+			err := beforeAllHook.BeforeAll()
+			metrics.ObservePhaseDuration(logging.BeforeAllTestPhase, startTime)
+			tracing.EndPhaseSpan(span, err)
+			logging.ClearTestContext()
+			if err != nil {
+				return stacktrace.Propagate(err, "An error occurred running the testsuite's BeforeAll hook")
+			}
+			logrus.Info("Ran the testsuite's BeforeAll hook")
+		}
+		if afterAllHook, ok := suite.(testsuite.AfterAllHook); ok {
+			defer runAfterAllHook(afterAllHook)
+		}
 	}
 
-	testsuiteService := NewTestSuiteService(suite, apiContainerService)
+	// Nothing is serving until the server is listening; the overall status would otherwise default to serving
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(overallHealthServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	healthServer.SetServingStatus(testSuiteServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+
+	testsuiteService := NewTestSuiteService(
+		suite,
+		apiContainerService,
+		healthServer,
+		executor.shutdownConfig.interruptedTestGracePeriod,
+	)
 	testsuiteServiceRegistrationFunc := func(grpcServer *grpc.Server) {
 		bindings.RegisterTestSuiteServiceServer(grpcServer, testsuiteService)
 	}
+	healthServiceRegistrationFunc := func(grpcServer *grpc.Server) {
+		grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	}
+	// Reflection lets generic tools like grpcurl call the testsuite without needing its .proto files
+	reflectionServiceRegistrationFunc := func(grpcServer *grpc.Server) {
+		reflection.Register(grpcServer)
+	}
 
-	testsuiteServer := server.NewMinimalGRPCServer(
-		rpc_api_consts.ListenPort,
-		rpc_api_consts.ListenProtocol,
-		grpcServerStopGracePeriod,
+	listenProtocol, listenAddress := parseListenAddress(executor.listenAddress)
+	serverOptions, err := executor.serverSecurityConfig.GetServerOptions()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the options for the testsuite server")
+	}
+	serverStopGracePeriod := executor.shutdownConfig.getServerStopGracePeriod()
+	var shutdownStartTime time.Time
+	testsuiteServer := newTestSuiteServer(
+		listenProtocol,
+		listenAddress,
+		executor.listenAddressOutputFilepath,
+		serverStopGracePeriod,
+		serverOptions,
 		[]func(desc *grpc.Server) {
 			testsuiteServiceRegistrationFunc,
+			healthServiceRegistrationFunc,
+			reflectionServiceRegistrationFunc,
+		},
+		func() {
+			healthServer.SetServingStatus(overallHealthServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+			healthServer.SetServingStatus(testSuiteServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+		},
+		func() {
+			shutdownStartTime = time.Now()
+			// Set first, so that health checkers stop sending us work while the running test is wrapping up
+			healthServer.Shutdown()
+			testsuiteService.interruptRunningTest()
 		},
 	)
-	if err := testsuiteServer.Run(); err != nil {
+	if err := testsuiteServer.run(); err != nil {
 		return stacktrace.Propagate(err, "An error occurred running the testsuite server")
 	}
 
+	// The server may have been hard-stopped before an interrupted test's teardown finished; the test doesn't get any
+	//  time beyond the server's stop grace period, as the rest of the shutdown timeout is reserved for the telemetry
+	if err := testsuiteService.waitForRunningTest(time.Until(shutdownStartTime.Add(serverStopGracePeriod))); err != nil {
+		logrus.Warnf("The interrupted test didn't finish cleanly; its teardown may not have completed: %v", err)
+	}
+	if interruptedTestName := testsuiteService.getInterruptedTestName(); interruptedTestName != "" {
+		return stacktrace.Propagate(
+			ErrTestInterrupted,
+			"The testsuite was shut down while test '%v' was running",
+			interruptedTestName,
+		)
+	}
+
 	return nil
 }
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Splits a listen address into the protocol and address that net.Listen expects
+func parseListenAddress(listenAddress string) (string, string) {
+	if strings.HasPrefix(listenAddress, unixSocketListenAddressPrefix) {
+		return unixSocketListenProtocol, strings.TrimPrefix(listenAddress, unixSocketListenAddressPrefix)
+	}
+	return rpc_api_consts.ListenProtocol, listenAddress
+}
+
+// By the time the AfterAll hook runs the testsuite server has stopped, so there's nobody to report an error to and
+//  we log it instead
+func runAfterAllHook(afterAllHook testsuite.AfterAllHook) {
+	logging.SetTestContext("", logging.AfterAllTestPhase)
+	defer logging.ClearTestContext()
+	logrus.Info("Running the testsuite's AfterAll hook...")
+	_, span := tracing.StartPhaseSpan(context.Background(), afterAllSpanName)
+	startTime := time.Now()
+	err := afterAllHook.AfterAll()
+	metrics.ObservePhaseDuration(logging.AfterAllTestPhase, startTime)
+	tracing.EndPhaseSpan(span, err)
+	if err != nil {
+		logrus.Errorf("An error occurred running the testsuite's AfterAll hook:")
+		fmt.Fprintln(logrus.StandardLogger().Out, err)
+		return
+	}
+	logrus.Info("Ran the testsuite's AfterAll hook")
+}
@@ -0,0 +1,159 @@
+package execution
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+const (
+	listenAddressOutputFilePerms = 0644
+)
+
+// A gRPC server that serves until it receives a termination signal
+// NOTE: This is the same as the MinimalGRPCServer from the minimal-grpc-server library, except that it accepts
+//  gRPC server options (which we need for TLS & auth interceptors), an arbitrary listen address, and a hook for
+//  interrupting in-flight work on shutdown
+type testSuiteServer struct {
+	listenProtocol string
+	listenAddress string
+	listenAddressOutputFilepath string  // If non-empty, the address the server ends up listening on will be written here
+	stopGracePeriod time.Duration  // How long we'll give the server to stop after asking nicely before we kill it
+	serverOptions []grpc.ServerOption
+	serviceRegistrationFuncs []func(*grpc.Server)
+
+	// Called once the server is listening, so callers can start advertising that it's ready for calls
+	onListening func()
+
+	// Called when a shutdown signal is received, before the server starts waiting for in-flight calls to finish
+	onShutdownSignal func()
+}
+
+func newTestSuiteServer(
+		listenProtocol string,
+		listenAddress string,
+		listenAddressOutputFilepath string,
+		stopGracePeriod time.Duration,
+		serverOptions []grpc.ServerOption,
+		serviceRegistrationFuncs []func(*grpc.Server),
+		onListening func(),
+		onShutdownSignal func()) *testSuiteServer {
+	return &testSuiteServer{
+		listenProtocol: listenProtocol,
+		listenAddress: listenAddress,
+		listenAddressOutputFilepath: listenAddressOutputFilepath,
+		stopGracePeriod: stopGracePeriod,
+		serverOptions: serverOptions,
+		serviceRegistrationFuncs: serviceRegistrationFuncs,
+		onListening: onListening,
+		onShutdownSignal: onShutdownSignal,
+	}
+}
+
+func (server testSuiteServer) run() error {
+	grpcServer := grpc.NewServer(server.serverOptions...)
+
+	for _, registrationFunc := range server.serviceRegistrationFuncs {
+		registrationFunc(grpcServer)
+	}
+
+	// A socket file left behind by a previous run that didn't shut down cleanly would make the listen fail
+	if server.listenProtocol == unixSocketListenProtocol {
+		if err := removeStaleUnixSocket(server.listenAddress); err != nil {
+			return stacktrace.Propagate(err, "An error occurred removing a stale Unix socket at '%v'", server.listenAddress)
+		}
+	}
+
+	listener, err := net.Listen(server.listenProtocol, server.listenAddress)
+	if err != nil {
+		return stacktrace.Propagate(
+			err,
+			"An error occurred creating the listener on %v/%v",
+			server.listenProtocol,
+			server.listenAddress,
+		)
+	}
+
+	// The actual address can differ from the requested one (e.g. when port 0 was requested), so we report it for
+	//  whoever needs to connect to us
+	actualListenAddress := listener.Addr().String()
+	logrus.Infof("Testsuite server listening on %v address '%v'", server.listenProtocol, actualListenAddress)
+	if server.listenAddressOutputFilepath != "" {
+		if err := ioutil.WriteFile(server.listenAddressOutputFilepath, []byte(actualListenAddress), listenAddressOutputFilePerms); err != nil {
+			listener.Close()
+			return stacktrace.Propagate(
+				err,
+				"An error occurred writing the listen address to file '%v'",
+				server.listenAddressOutputFilepath,
+			)
+		}
+	}
+
+	// Signals are used to interrupt the server, so we catch them here
+	termSignalChan := make(chan os.Signal, 1)
+	signal.Notify(termSignalChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+
+	grpcServerResultChan := make(chan error)
+
+	go func() {
+		var resultErr error = nil
+		if err := grpcServer.Serve(listener); err != nil {
+			resultErr = stacktrace.Propagate(err, "The gRPC server exited with an error")
+		}
+		grpcServerResultChan <- resultErr
+	}()
+	// Connections made to the listener before Serve is called just queue up, so it's safe to advertise readiness now
+	server.onListening()
+
+	// Wait until we get a shutdown signal
+	receivedSignal := <- termSignalChan
+	logrus.Infof("Received signal '%v'; shutting down the testsuite server...", receivedSignal)
+	server.onShutdownSignal()
+
+	serverStoppedChan := make(chan interface{})
+	go func() {
+		grpcServer.GracefulStop()
+		serverStoppedChan <- nil
+	}()
+	select {
+	case <- serverStoppedChan:
+		logrus.Debug("gRPC server has exited gracefully")
+	case <- time.After(server.stopGracePeriod):
+		logrus.Warnf("gRPC server failed to stop gracefully after %v; hard-stopping now...", server.stopGracePeriod)
+		grpcServer.Stop()
+		logrus.Debug("gRPC server was forcefully stopped")
+	}
+	if err := <- grpcServerResultChan; err != nil {
+		// Technically this doesn't need to be an error, but we make it so to fail loudly
+		return stacktrace.Propagate(err, "gRPC server returned an error after it was done serving")
+	}
+
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Only removes the file if it's a socket, so that a mistyped socket filepath can't delete anything else
+func removeStaleUnixSocket(socketFilepath string) error {
+	fileInfo, err := os.Lstat(socketFilepath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting info for file '%v'", socketFilepath)
+	}
+	if fileInfo.Mode()&os.ModeSocket == 0 {
+		return stacktrace.NewError("File '%v' already exists and isn't a Unix socket", socketFilepath)
+	}
+	if err := os.Remove(socketFilepath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing Unix socket file '%v'", socketFilepath)
+	}
+	return nil
+}
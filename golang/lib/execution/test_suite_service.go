@@ -2,14 +2,24 @@ package execution
 
 import (
 	"context"
+	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/core_api_bindings"
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/dependency_graph"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/metrics"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/rpc_api_consts"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/tracing"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"strings"
 	"sync"
+	"time"
 )
 
 type testSetupInfo struct {
@@ -17,23 +27,69 @@ type testSetupInfo struct {
 	testName string
 }
 
+const (
+	// Pseudo-service whose health status is SERVING only while a test is running, so that health checking tools can
+	//  tell whether the testsuite is busy
+	runningTestHealthServiceName = "test_suite_api.TestSuiteService.RunningTest"
+
+	getTestSuiteMetadataSpanName = "GetTestSuiteMetadata"
+	setupTestSpanName = "SetupTest"
+	runTestSpanName = "RunTest"
+	teardownTestSpanName = "Teardown"
+)
+
+// Information about the test whose logic is currently executing, so that it can be interrupted on shutdown
+type runningTestInfo struct {
+	testName string
+	cancelFunc context.CancelFunc
+
+	// Closed when the test (and its teardown) has finished
+	doneChan chan struct{}
+}
+
 type TestSuiteService struct {
 	suite testsuite.TestSuite
 
+	// Used to report whether a test is running to generic gRPC health checking tools
+	healthServer *health.Server
+
 	// This will only be non-empty after SetupTest is called
 	testSetupInfo *testSetupInfo
 
 	testSetupInfoMutex *sync.Mutex
 
+	// This will only be non-nil while RunTest is executing a test
+	// NOTE: This has its own mutex because testSetupInfoMutex is held for the entire test run, and we need to be able
+	//  to interrupt the test while it's running
+	runningTest *runningTestInfo
+
+	// Will be non-empty if a test was interrupted because the testsuite was shutting down
+	interruptedTestName string
+
+	runningTestMutex *sync.Mutex
+
+	// How long an interrupted test gets to return from its run before its teardown is run anyway
+	interruptedTestGracePeriod time.Duration
+
 	// Will only be non-nil if an IP:port to a Kurtosis API container was provided
 	kurtosisApiClient core_api_bindings.ApiContainerServiceClient
 }
 
-func NewTestSuiteService(suite testsuite.TestSuite, kurtosisApiClient core_api_bindings.ApiContainerServiceClient) *TestSuiteService {
+func NewTestSuiteService(
+		suite testsuite.TestSuite,
+		kurtosisApiClient core_api_bindings.ApiContainerServiceClient,
+		healthServer *health.Server,
+		interruptedTestGracePeriod time.Duration) *TestSuiteService {
+	healthServer.SetServingStatus(runningTestHealthServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
 	return &TestSuiteService{
 		suite:              suite,
+		healthServer:       healthServer,
 		testSetupInfo:      nil,
 		testSetupInfoMutex: &sync.Mutex{},
+		runningTest:        nil,
+		interruptedTestName: "",
+		runningTestMutex:   &sync.Mutex{},
+		interruptedTestGracePeriod: interruptedTestGracePeriod,
 		kurtosisApiClient:  kurtosisApiClient,
 	}
 }
@@ -42,12 +98,25 @@ func (service TestSuiteService) IsAvailable(_ context.Context, _ *emptypb.Empty)
 	return &emptypb.Empty{}, nil
 }
 
-func (service TestSuiteService) GetTestSuiteMetadata(ctx context.Context, empty *emptypb.Empty) (*bindings.TestSuiteMetadata, error) {
+func (service TestSuiteService) GetTestSuiteMetadata(ctx context.Context, empty *emptypb.Empty) (resultMetadata *bindings.TestSuiteMetadata, resultErr error) {
+	_, span := tracing.StartPhaseSpan(ctx, getTestSuiteMetadataSpanName)
+	defer func() {
+		tracing.EndPhaseSpan(span, resultErr)
+	}()
+
 	allTestMetadata := map[string]*bindings.TestMetadata{}
+	allTestDependencies := map[string]map[string]bool{}
 	for testName, test := range service.suite.GetTests() {
 		testConfigBuilder := testsuite.NewTestConfigurationBuilder()
 		test.Configure(testConfigBuilder)
 		testConfig := testConfigBuilder.Build()
+		if testConfig.NetworkTemplateName != "" {
+			// Checked here so that a typo in a template name fails the whole suite up front, rather than each test
+			//  that uses it failing during setup
+			if _, err := getNetworkTemplate(service.suite, testConfig.NetworkTemplateName); err != nil {
+				return nil, stacktrace.Propagate(err, "Test '%v' references an invalid network template", testName)
+			}
+		}
 		usedArtifactUrls := map[string]bool{}
 		for _, artifactUrl := range testConfig.FilesArtifactUrls {
 			usedArtifactUrls[artifactUrl] = true
@@ -57,23 +126,48 @@ func (service TestSuiteService) GetTestSuiteMetadata(ctx context.Context, empty
 			UsedArtifactUrls:      usedArtifactUrls,
 			TestSetupTimeoutInSeconds: testConfig.SetupTimeoutSeconds,
 			TestRunTimeoutInSeconds: testConfig.RunTimeoutSeconds,
+			TestDependencies: testConfig.Dependencies,
+			Priority: testConfig.Priority,
 		}
 		allTestMetadata[testName] = testMetadata
+		allTestDependencies[testName] = testConfig.Dependencies
+	}
+	if err := validateTestDependencies(allTestDependencies); err != nil {
+		return nil, stacktrace.Propagate(err, "The testsuite's test dependencies are invalid")
+	}
+
+	isFailFast := false
+	if failFastPolicyProvider, ok := service.suite.(testsuite.FailFastPolicyProvider); ok {
+		isFailFast = failFastPolicyProvider.IsFailFast()
 	}
 
 	networkWidthBits := service.suite.GetNetworkWidthBits()
 	testSuiteMetadata := &bindings.TestSuiteMetadata{
 		TestMetadata:     allTestMetadata,
 		NetworkWidthBits: networkWidthBits,
+		IsFailFast:       isFailFast,
+		SuiteApiVersion:  rpc_api_consts.SuiteApiVersion,
+		LibVersion:       rpc_api_consts.LibVersion,
+		Capabilities: map[string]bool{
+			rpc_api_consts.GracefulShutdownCapability: true,
+			rpc_api_consts.TestDependenciesCapability: true,
+			rpc_api_consts.GrpcHealthCapability:       true,
+			rpc_api_consts.ServerReflectionCapability: true,
+		},
 	}
 
 	return testSuiteMetadata, nil
 }
 
-func (service *TestSuiteService) SetupTest(ctx context.Context, args *bindings.SetupTestArgs) (*emptypb.Empty, error) {
+func (service *TestSuiteService) SetupTest(ctx context.Context, args *bindings.SetupTestArgs) (resultEmpty *emptypb.Empty, resultErr error) {
 	service.testSetupInfoMutex.Lock()
 	defer service.testSetupInfoMutex.Unlock()
 
+	_, span := tracing.StartPhaseSpan(ctx, setupTestSpanName, tracing.TestNameAttributeKey.String(args.TestName))
+	defer func() {
+		tracing.EndPhaseSpan(span, resultErr)
+	}()
+
 	if service.kurtosisApiClient == nil {
 		return nil, stacktrace.NewError("Received a request to setup the test, but the Kurtosis API container client is nil")
 	}
@@ -90,6 +184,18 @@ func (service *TestSuiteService) SetupTest(ctx context.Context, args *bindings.S
 		)
 	}
 
+	logging.SetTestContext(testName, logging.SetupTestPhase)
+	defer logging.ClearTestContext()
+
+	metrics.RecordTestStarted(testName)
+	setupStartTime := time.Now()
+	defer func() {
+		metrics.ObservePhaseDuration(logging.SetupTestPhase, setupStartTime)
+		if resultErr != nil {
+			metrics.RecordTestFinished(testName, false)
+		}
+	}()
+
 	logrus.Infof("Setting up network for test '%v'...", testName)
 	testConfigBuilder := testsuite.NewTestConfigurationBuilder()
 	test.Configure(testConfigBuilder)
@@ -101,6 +207,17 @@ func (service *TestSuiteService) SetupTest(ctx context.Context, args *bindings.S
 		filesArtifactUrls,
 	)
 
+	if testConfig.NetworkTemplateName != "" {
+		if err := applyNetworkTemplate(service.suite, testConfig.NetworkTemplateName, networkCtx); err != nil {
+			return nil, stacktrace.Propagate(
+				err,
+				"An error occurred applying network template '%v' for test '%v'",
+				testConfig.NetworkTemplateName,
+				testName,
+			)
+		}
+	}
+
 	userNetwork, err := test.Setup(networkCtx)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred during test setup")
@@ -114,7 +231,7 @@ func (service *TestSuiteService) SetupTest(ctx context.Context, args *bindings.S
 	return &emptypb.Empty{}, nil
 }
 
-func (service TestSuiteService) RunTest(ctx context.Context, empty *emptypb.Empty) (*emptypb.Empty, error) {
+func (service *TestSuiteService) RunTest(ctx context.Context, empty *emptypb.Empty) (resultEmpty *emptypb.Empty, resultErr error) {
 	service.testSetupInfoMutex.Lock()
 	defer service.testSetupInfoMutex.Unlock()
 
@@ -138,9 +255,79 @@ func (service TestSuiteService) RunTest(ctx context.Context, empty *emptypb.Empt
 		)
 	}
 
+	// The test's context descends from the span so that tests implementing CancellableTest can add their own spans
+	spanCtx, span := tracing.StartPhaseSpan(ctx, runTestSpanName, tracing.TestNameAttributeKey.String(testName))
+	defer func() {
+		tracing.EndPhaseSpan(span, resultErr)
+	}()
+
+	testCtx, cancelFunc := context.WithCancel(spanCtx)
+	doneChan := make(chan struct{})
+	service.setRunningTest(&runningTestInfo{
+		testName:   testName,
+		cancelFunc: cancelFunc,
+		doneChan:   doneChan,
+	})
+	defer func() {
+		service.setRunningTest(nil)
+		cancelFunc()
+		close(doneChan)
+	}()
+
+	logging.SetTestContext(testName, logging.RunTestPhase)
+	defer logging.ClearTestContext()
+	defer func() {
+		metrics.RecordTestFinished(testName, resultErr == nil)
+	}()
+
 	logrus.Infof("Running test logic for test '%v'...", testName)
-	if err := runTest(test, network); err != nil {
-		return nil, stacktrace.NewError(
+	runStartTime := time.Now()
+	// The test is run in the background so that, if it's interrupted and doesn't return (e.g. because it doesn't
+	//  implement CancellableTest), its teardown still gets run before the testsuite exits
+	runErrChan := make(chan error, 1)
+	go func() {
+		runErrChan <- runTest(testCtx, test, network)
+	}()
+	var runErr error
+	select {
+	case runErr = <- runErrChan:
+	case <- testCtx.Done():
+		select {
+		case runErr = <- runErrChan:
+		case <- time.After(service.interruptedTestGracePeriod):
+			logrus.Warnf(
+				"Test '%v' didn't return within %v of being interrupted; running its teardown while it's still running...",
+				testName,
+				service.interruptedTestGracePeriod,
+			)
+			runErr = stacktrace.NewError(
+				"Test '%v' didn't return within %v of being interrupted",
+				testName,
+				service.interruptedTestGracePeriod,
+			)
+		}
+	}
+	metrics.ObservePhaseDuration(logging.RunTestPhase, runStartTime)
+	if teardownHook, ok := test.(testsuite.TeardownHook); ok {
+		logging.SetTestContext(testName, logging.TeardownTestPhase)
+		// The teardown is run regardless of whether the test succeeded, as cleaning up is most important on failure
+		logrus.Infof("Running teardown for test '%v'...", testName)
+		_, teardownSpan := tracing.StartPhaseSpan(spanCtx, teardownTestSpanName, tracing.TestNameAttributeKey.String(testName))
+		teardownStartTime := time.Now()
+		teardownErr := runTeardown(teardownHook, network)
+		metrics.ObservePhaseDuration(logging.TeardownTestPhase, teardownStartTime)
+		tracing.EndPhaseSpan(teardownSpan, teardownErr)
+		if teardownErr != nil {
+			logrus.Errorf("An error occurred running the teardown for test '%v':", testName)
+			fmt.Fprintln(logrus.StandardLogger().Out, teardownErr)
+		} else {
+			logrus.Infof("Ran teardown for test '%v'", testName)
+		}
+		logging.SetTestContext(testName, logging.RunTestPhase)
+	}
+	if runErr != nil {
+		return nil, stacktrace.Propagate(
+			runErr,
 			"An error occurred running test '%v'",
 			testName,
 		)
@@ -149,18 +336,143 @@ func (service TestSuiteService) RunTest(ctx context.Context, empty *emptypb.Empt
 	return &emptypb.Empty{}, nil
 }
 
+// Cancels the context of the currently-running test (if any), so that it can stop early
+func (service *TestSuiteService) interruptRunningTest() {
+	service.runningTestMutex.Lock()
+	defer service.runningTestMutex.Unlock()
+	if service.runningTest == nil {
+		return
+	}
+	logrus.Warnf("Interrupting test '%v' because the testsuite is shutting down...", service.runningTest.testName)
+	service.interruptedTestName = service.runningTest.testName
+	service.runningTest.cancelFunc()
+}
+
+// Waits for the currently-running test (if any), including its teardown, to finish
+func (service *TestSuiteService) waitForRunningTest(timeout time.Duration) error {
+	service.runningTestMutex.Lock()
+	runningTest := service.runningTest
+	service.runningTestMutex.Unlock()
+	if runningTest == nil {
+		return nil
+	}
+	// Checked separately first so that a test that's already finished is never reported as timed out
+	select {
+	case <- runningTest.doneChan:
+		return nil
+	default:
+	}
+	select {
+	case <- runningTest.doneChan:
+		return nil
+	case <- time.After(timeout):
+		return stacktrace.NewError("Test '%v' didn't finish within %v of being interrupted", runningTest.testName, timeout)
+	}
+}
+
+func (service *TestSuiteService) getInterruptedTestName() string {
+	service.runningTestMutex.Lock()
+	defer service.runningTestMutex.Unlock()
+	return service.interruptedTestName
+}
+
+func (service *TestSuiteService) setRunningTest(runningTest *runningTestInfo) {
+	service.runningTestMutex.Lock()
+	defer service.runningTestMutex.Unlock()
+	service.runningTest = runningTest
+
+	runningTestHealthStatus := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if runningTest != nil {
+		runningTestHealthStatus = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	service.healthServer.SetServingStatus(runningTestHealthServiceName, runningTestHealthStatus)
+}
+
+// Verifies that every dependency refers to a test in the suite, and that there are no dependency cycles (which would
+//  mean none of the tests in the cycle could ever run)
+func validateTestDependencies(allTestDependencies map[string]map[string]bool) error {
+	for testName, dependencies := range allTestDependencies {
+		for dependencyName := range dependencies {
+			if _, found := allTestDependencies[dependencyName]; !found {
+				return stacktrace.NewError(
+					"Test '%v' depends on test '%v', but no test with that name exists in the testsuite",
+					testName,
+					dependencyName,
+				)
+			}
+		}
+	}
+
+	if cycle := dependency_graph.FindCycle(allTestDependencies); cycle != nil {
+		return stacktrace.NewError("Found a test dependency cycle: %v", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
+
+func getNetworkTemplate(suite testsuite.TestSuite, networkTemplateName string) (testsuite.NetworkTemplate, error) {
+	templateProvider, ok := suite.(testsuite.NetworkTemplateProvider)
+	if !ok {
+		return nil, stacktrace.NewError(
+			"Network template '%v' was requested, but the testsuite doesn't provide any network templates",
+			networkTemplateName,
+		)
+	}
+	networkTemplate, found := templateProvider.GetNetworkTemplates()[networkTemplateName]
+	if !found {
+		return nil, stacktrace.NewError("The testsuite doesn't provide a network template named '%v'", networkTemplateName)
+	}
+	return networkTemplate, nil
+}
+
+// The Kurtosis API doesn't yet support snapshotting a network's services and volumes, so for now every test that uses
+//  a template gets the template rebuilt from scratch in its own network
+func applyNetworkTemplate(suite testsuite.TestSuite, networkTemplateName string, networkCtx *networks.NetworkContext) error {
+	networkTemplate, err := getNetworkTemplate(suite, networkTemplateName)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting network template '%v'", networkTemplateName)
+	}
+	logrus.Infof("Building network template '%v' from scratch, as the Kurtosis API doesn't support network snapshots...", networkTemplateName)
+	if err := networkTemplate(networkCtx); err != nil {
+		return stacktrace.Propagate(err, "An error occurred building network template '%v'", networkTemplateName)
+	}
+	logrus.Infof("Built network template '%v'", networkTemplateName)
+	return nil
+}
+
 // Little helper function that runs the test and captures panics on test failures, returning them as errors
-func runTest(test testsuite.Test, untypedNetwork interface{}) (resultErr error) {
+func runTest(ctx context.Context, test testsuite.Test, network networks.Network) (resultErr error) {
 	// See https://medium.com/@hussachai/error-handling-in-go-a-quick-opinionated-guide-9199dd7c7f76 for details
 	defer func() {
 		if recoverResult := recover(); recoverResult != nil {
 			logrus.Tracef("Caught panic while running test: %v", recoverResult)
-			resultErr = recoverResult.(error)
+			// The panic value needn't be an error (e.g. panic("boom")), and this may be running after RunTest has
+			//  returned, so a failed type assertion here would crash the whole testsuite
+			resultErr = stacktrace.NewError("The test panicked: %v", recoverResult)
 		}
 	}()
-	if err := test.Run(untypedNetwork); err != nil {
-		return stacktrace.Propagate(err, "The test returned an error")
+	var testErr error
+	if cancellableTest, ok := test.(testsuite.CancellableTest); ok {
+		testErr = cancellableTest.RunWithContext(ctx, network)
+	} else {
+		testErr = test.Run(network)
+	}
+	if testErr != nil {
+		return stacktrace.Propagate(testErr, "The test returned an error")
 	}
 	logrus.Tracef("Test completed successfully")
 	return
 }
+
+// Like runTest, but for the test's teardown
+func runTeardown(teardownHook testsuite.TeardownHook, network networks.Network) (resultErr error) {
+	defer func() {
+		if recoverResult := recover(); recoverResult != nil {
+			logrus.Tracef("Caught panic while running teardown: %v", recoverResult)
+			resultErr = stacktrace.NewError("The teardown panicked: %v", recoverResult)
+		}
+	}()
+	if err := teardownHook.Teardown(network); err != nil {
+		return stacktrace.Propagate(err, "The teardown returned an error")
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+	"strings"
+	"testing"
+)
+
+type funcTest struct {
+	runFunc func() error
+}
+
+func (test funcTest) Configure(builder *testsuite.TestConfigurationBuilder) {}
+
+func (test funcTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+	return nil, nil
+}
+
+func (test funcTest) Run(network networks.Network) error {
+	return test.runFunc()
+}
+
+func (test funcTest) Teardown(network networks.Network) error {
+	return test.runFunc()
+}
+
+func TestRunTestAndTeardownRecoverPanics(t *testing.T) {
+	testCases := []struct {
+		name string
+		runFunc func() error
+		// Must appear in the error; empty if no error is expected
+		expectedErrSubstring string
+	}{
+		{
+			name: "success",
+			runFunc: func() error { return nil },
+		},
+		{
+			name: "returned error",
+			runFunc: func() error { return errors.New("returned") },
+			expectedErrSubstring: "returned",
+		},
+		{
+			name: "panic with string",
+			runFunc: func() error { panic("boom") },
+			expectedErrSubstring: "panicked: boom",
+		},
+		{
+			name: "panic with error",
+			runFunc: func() error { panic(errors.New("failed assertion")) },
+			expectedErrSubstring: "panicked: failed assertion",
+		},
+		{
+			name: "panic with non-string value",
+			runFunc: func() error { panic(42) },
+			expectedErrSubstring: "panicked: 42",
+		},
+	}
+	for _, testCase := range testCases {
+		test := funcTest{runFunc: testCase.runFunc}
+		t.Run(testCase.name + " (test)", func(t *testing.T) {
+			assertErrContains(t, runTest(context.Background(), test, nil), testCase.expectedErrSubstring)
+		})
+		t.Run(testCase.name + " (teardown)", func(t *testing.T) {
+			assertErrContains(t, runTeardown(test, nil), testCase.expectedErrSubstring)
+		})
+	}
+}
+
+// The test logic runs in its own goroutine, where an unrecovered panic would crash the whole testsuite
+func TestRunTestRecoversPanicsInGoroutine(t *testing.T) {
+	test := funcTest{runFunc: func() error { panic("boom") }}
+	resultChan := make(chan error, 1)
+	go func() {
+		resultChan <- runTest(context.Background(), test, nil)
+	}()
+	assertErrContains(t, <-resultChan, "The test panicked: boom")
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func assertErrContains(t *testing.T, err error, expectedErrSubstring string) {
+	if expectedErrSubstring == "" {
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("Expected an error containing '%v', but got none", expectedErrSubstring)
+	}
+	if !strings.Contains(err.Error(), expectedErrSubstring) {
+		t.Fatalf("Expected the error to contain '%v', but got: %v", expectedErrSubstring, err)
+	}
+}
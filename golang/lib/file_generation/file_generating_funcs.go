@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package file_generation
+
+import (
+	"github.com/palantir/stacktrace"
+	"io/fs"
+	"os"
+	"path"
+	"text/template"
+)
+
+const (
+	toJsonFuncName = "toJson"
+	toYamlFuncName = "toYaml"
+	toTomlFuncName = "toToml"
+	toIniFuncName  = "toIni"
+
+	templateDirGlob = "*"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func GetTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		toJsonFuncName: func(obj interface{}) (string, error) {
+			return serializeToString(serializeJson, obj)
+		},
+		toYamlFuncName: func(obj interface{}) (string, error) {
+			return serializeToString(serializeYaml, obj)
+		},
+		toTomlFuncName: func(obj interface{}) (string, error) {
+			return serializeToString(serializeToml, obj)
+		},
+		toIniFuncName: func(sections IniSections) (string, error) {
+			result, err := serializeIni(sections)
+			if err != nil {
+				return "", stacktrace.Propagate(err, "An error occurred serializing the INI sections")
+			}
+			return string(result), nil
+		},
+	}
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func NewTemplateFileGeneratingFunc(templateStr string, context *TemplateContext) (func(*os.File) error, error) {
+	tmpl, err := template.New("").Funcs(GetTemplateFuncs()).Parse(templateStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the file template")
+	}
+	return newExecutingFileGeneratingFunc(tmpl, context), nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func NewTemplateSetFileGeneratingFuncs(templateSet *template.Template, context *TemplateContext) map[string]func(*os.File) error {
+	result := map[string]func(*os.File) error{}
+	for _, tmpl := range templateSet.Templates() {
+		result[tmpl.Name()] = newExecutingFileGeneratingFunc(tmpl, context)
+	}
+	return result
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func ParseTemplateDir(templateFs fs.FS, templateDirpath string) (*template.Template, error) {
+	// Paths in an fs.FS are always slash-separated, regardless of OS
+	globMatches, err := fs.Glob(templateFs, path.Join(templateDirpath, templateDirGlob))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred listing the files in template directory '%v'", templateDirpath)
+	}
+	templateFilepaths := []string{}
+	for _, match := range globMatches {
+		matchInfo, err := fs.Stat(templateFs, match)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting info for template directory entry '%v'", match)
+		}
+		if !matchInfo.IsDir() {
+			templateFilepaths = append(templateFilepaths, match)
+		}
+	}
+	if len(templateFilepaths) == 0 {
+		return nil, stacktrace.NewError("Template directory '%v' doesn't contain any templates", templateDirpath)
+	}
+	result, err := template.New("").Funcs(GetTemplateFuncs()).ParseFS(templateFs, templateFilepaths...)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the templates in directory '%v'", templateDirpath)
+	}
+	return result, nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func NewJsonFileGeneratingFunc(obj interface{}) func(*os.File) error {
+	return newSerializingFileGeneratingFunc(serializeJson, obj)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func NewYamlFileGeneratingFunc(obj interface{}) func(*os.File) error {
+	return newSerializingFileGeneratingFunc(serializeYaml, obj)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func NewTomlFileGeneratingFunc(obj interface{}) func(*os.File) error {
+	return newSerializingFileGeneratingFunc(serializeToml, obj)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func NewIniFileGeneratingFunc(sections IniSections) func(*os.File) error {
+	return func(fp *os.File) error {
+		fileBytes, err := serializeIni(sections)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred serializing the INI sections")
+		}
+		if _, err := fp.Write(fileBytes); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing the serialized INI to file")
+		}
+		return nil
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func newExecutingFileGeneratingFunc(tmpl *template.Template, context *TemplateContext) func(*os.File) error {
+	return func(fp *os.File) error {
+		if err := tmpl.Execute(fp, context); err != nil {
+			return stacktrace.Propagate(err, "An error occurred rendering template '%v' to file", tmpl.Name())
+		}
+		return nil
+	}
+}
+
+func newSerializingFileGeneratingFunc(serializer func(interface{}) ([]byte, error), obj interface{}) func(*os.File) error {
+	return func(fp *os.File) error {
+		fileBytes, err := serializer(obj)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred serializing the file contents")
+		}
+		if _, err := fp.Write(fileBytes); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing the serialized contents to file")
+		}
+		return nil
+	}
+}
+
+func serializeToString(serializer func(interface{}) ([]byte, error), obj interface{}) (string, error) {
+	result, err := serializer(obj)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred serializing the object to a string")
+	}
+	return string(result), nil
+}
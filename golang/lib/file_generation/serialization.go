@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package file_generation
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/palantir/stacktrace"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strings"
+)
+
+const (
+	// INI keys in this section are written at the top of the file, before any section header
+	IniGlobalSectionName = ""
+
+	// Characters that would change how an INI line is parsed if they appeared in a key or section name
+	iniDisallowedKeyChars = "=[]\r\n"
+	iniDisallowedSectionNameChars = "[]\r\n"
+	iniCommentPrefixes = ";#"
+
+	// Values containing any of these, or with leading or trailing whitespace, are quoted so parsers read them verbatim
+	iniValueCharsRequiringQuotes = "\"\\;#\r\n"
+	iniQuote = `"`
+)
+
+var iniQuotedValueEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`"`, `\"`,
+	"\r", `\r`,
+	"\n", `\n`,
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type IniSections map[string]map[string]interface{}
+
+func serializeJson(obj interface{}) ([]byte, error) {
+	result, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing object '%+v' to JSON", obj)
+	}
+	return result, nil
+}
+
+func serializeYaml(obj interface{}) ([]byte, error) {
+	result, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing object '%+v' to YAML", obj)
+	}
+	return result, nil
+}
+
+func serializeToml(obj interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	if err := toml.NewEncoder(buffer).Encode(obj); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing object '%+v' to TOML", obj)
+	}
+	return buffer.Bytes(), nil
+}
+
+// INI has no real standard, so we write the most widely-understood subset: "key = value" lines grouped under
+// "[section]" headers, with sections and keys sorted so the output is deterministic
+func serializeIni(sections IniSections) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+
+	sectionNames := []string{}
+	for sectionName := range sections {
+		if sectionName != IniGlobalSectionName {
+			sectionNames = append(sectionNames, sectionName)
+		}
+	}
+	sort.Strings(sectionNames)
+
+	if globalKeys, found := sections[IniGlobalSectionName]; found {
+		if err := writeIniKeys(buffer, globalKeys); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred writing the INI keys that come before any section")
+		}
+		if len(sectionNames) > 0 {
+			buffer.WriteString("\n")
+		}
+	}
+
+	for idx, sectionName := range sectionNames {
+		if idx > 0 {
+			buffer.WriteString("\n")
+		}
+		if strings.ContainsAny(sectionName, iniDisallowedSectionNameChars) {
+			return nil, stacktrace.NewError("INI section name '%v' contains one of the disallowed characters %q", sectionName, iniDisallowedSectionNameChars)
+		}
+		if _, err := fmt.Fprintf(buffer, "[%v]\n", sectionName); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred writing the header for INI section '%v'", sectionName)
+		}
+		if err := writeIniKeys(buffer, sections[sectionName]); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred writing the keys of INI section '%v'", sectionName)
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeIniKeys(buffer *bytes.Buffer, keyValues map[string]interface{}) error {
+	keys := []string{}
+	for key := range keyValues {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if strings.TrimSpace(key) == "" {
+			return stacktrace.NewError("INI keys can't be empty or all whitespace")
+		}
+		if strings.ContainsAny(key, iniDisallowedKeyChars) {
+			return stacktrace.NewError("INI key '%v' contains one of the disallowed characters %q", key, iniDisallowedKeyChars)
+		}
+		if strings.ContainsAny(key[:1], iniCommentPrefixes) {
+			return stacktrace.NewError("INI key '%v' starts with a comment character", key)
+		}
+		// Writes to a bytes.Buffer can't fail, so there's no error to check
+		fmt.Fprintf(buffer, "%v = %v\n", key, formatIniValue(keyValues[key]))
+	}
+	return nil
+}
+
+func formatIniValue(value interface{}) string {
+	valueStr := fmt.Sprint(value)
+	isQuotingRequired := strings.ContainsAny(valueStr, iniValueCharsRequiringQuotes) ||
+		strings.TrimSpace(valueStr) != valueStr
+	if !isQuotingRequired {
+		return valueStr
+	}
+	return iniQuote + iniQuotedValueEscaper.Replace(valueStr) + iniQuote
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package file_generation
+
+import (
+	"testing"
+)
+
+func TestSerializeIni(t *testing.T) {
+	testCases := []struct {
+		name string
+		sections IniSections
+		expected string
+	}{
+		{
+			name: "global keys come before sorted sections",
+			sections: IniSections{
+				"server": {"port": 8080, "host": "localhost"},
+				IniGlobalSectionName: {"name": "test"},
+				"database": {"user": "admin"},
+			},
+			expected: "name = test\n\n[database]\nuser = admin\n\n[server]\nhost = localhost\nport = 8080\n",
+		},
+		{
+			name: "plain values aren't quoted",
+			sections: IniSections{
+				"section": {"url": "http://1.2.3.4:80/path?a=b", "enabled": true, "ratio": 0.5},
+			},
+			expected: "[section]\nenabled = true\nratio = 0.5\nurl = http://1.2.3.4:80/path?a=b\n",
+		},
+		{
+			name: "values with comment characters are quoted",
+			sections: IniSections{
+				"section": {"semicolon": "a;b", "hash": "#notacomment"},
+			},
+			expected: "[section]\nhash = \"#notacomment\"\nsemicolon = \"a;b\"\n",
+		},
+		{
+			name: "quotes, backslashes and newlines are escaped",
+			sections: IniSections{
+				"section": {"quote": `say "hi"`, "backslash": `C:\dir`, "multiline": "line1\nline2\r\n"},
+			},
+			expected: "[section]\nbackslash = \"C:\\\\dir\"\nmultiline = \"line1\\nline2\\r\\n\"\nquote = \"say \\\"hi\\\"\"\n",
+		},
+		{
+			name: "values with surrounding whitespace are quoted",
+			sections: IniSections{
+				"section": {"padded": "  value ", "empty": ""},
+			},
+			expected: "[section]\nempty = \npadded = \"  value \"\n",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := serializeIni(testCase.sections)
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if string(result) != testCase.expected {
+				t.Fatalf("Expected INI:\n%v\nbut got:\n%v", testCase.expected, string(result))
+			}
+		})
+	}
+}
+
+func TestSerializeIniRejectsUnrepresentableNames(t *testing.T) {
+	testCases := []struct {
+		name string
+		sections IniSections
+	}{
+		{name: "key containing equals", sections: IniSections{"section": {"a=b": 1}}},
+		{name: "key containing newline", sections: IniSections{"section": {"a\nb": 1}}},
+		{name: "key starting with comment character", sections: IniSections{"section": {";key": 1}}},
+		{name: "empty key", sections: IniSections{IniGlobalSectionName: {"": 1}}},
+		{name: "section name containing bracket", sections: IniSections{"a]b": {"key": 1}}},
+		{name: "section name containing newline", sections: IniSections{"a\nb": {"key": 1}}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if _, err := serializeIni(testCase.sections); err == nil {
+				t.Fatalf("Expected an error serializing sections %v, but got none", testCase.sections)
+			}
+		})
+	}
+}
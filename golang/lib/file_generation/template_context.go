@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package file_generation
+
+import "fmt"
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type ServiceAddress struct {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	IpAddr string
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Port int
+}
+
+// Renders the address in IP:port form, so templates can use {{ .Services.foo }} directly
+func (address ServiceAddress) String() string {
+	return fmt.Sprintf("%v:%v", address.IpAddr, address.Port)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type TemplateContext struct {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	ContainerIpAddr string
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Ports map[string]int
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Services map[string]ServiceAddress
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Values map[string]interface{}
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type TemplateContextBuilder struct {
+	containerIpAddr string
+	ports           map[string]int
+	services        map[string]ServiceAddress
+	values          map[string]interface{}
+}
+
+func NewTemplateContextBuilder(containerIpAddr string) *TemplateContextBuilder {
+	return &TemplateContextBuilder{
+		containerIpAddr: containerIpAddr,
+		ports:           map[string]int{},
+		services:        map[string]ServiceAddress{},
+		values:          map[string]interface{}{},
+	}
+}
+
+func (builder *TemplateContextBuilder) WithPort(portName string, port int) *TemplateContextBuilder {
+	builder.ports[portName] = port
+	return builder
+}
+
+func (builder *TemplateContextBuilder) WithServiceAddress(serviceName string, ipAddr string, port int) *TemplateContextBuilder {
+	builder.services[serviceName] = ServiceAddress{
+		IpAddr: ipAddr,
+		Port:   port,
+	}
+	return builder
+}
+
+func (builder *TemplateContextBuilder) WithValue(key string, value interface{}) *TemplateContextBuilder {
+	builder.values[key] = value
+	return builder
+}
+
+func (builder TemplateContextBuilder) Build() *TemplateContext {
+	ports := map[string]int{}
+	for name, port := range builder.ports {
+		ports[name] = port
+	}
+	services := map[string]ServiceAddress{}
+	for name, address := range builder.services {
+		services[name] = address
+	}
+	values := map[string]interface{}{}
+	for key, value := range builder.values {
+		values[key] = value
+	}
+	return &TemplateContext{
+		ContainerIpAddr: builder.containerIpAddr,
+		Ports:           ports,
+		Services:        services,
+		Values:          values,
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package logging
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"sync"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type LogFormat string
+
+// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+const (
+	// Human-readable, colored output
+	TextLogFormat LogFormat = "text"
+
+	// One JSON object per line
+	JsonLogFormat LogFormat = "json"
+
+	// One line of space-separated key=value pairs per entry
+	LogfmtLogFormat LogFormat = "logfmt"
+)
+
+const (
+	TestNameLogField = "testName"
+	TestPhaseLogField = "phase"
+	ServiceIdLogField = "serviceId"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type TestPhase string
+
+const (
+	BeforeAllTestPhase TestPhase = "beforeAll"
+	SetupTestPhase TestPhase = "setup"
+	RunTestPhase TestPhase = "run"
+	TeardownTestPhase TestPhase = "teardown"
+	AfterAllTestPhase TestPhase = "afterAll"
+)
+// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
+
+var logFormatters = map[LogFormat]func() logrus.Formatter{
+	TextLogFormat: func() logrus.Formatter {
+		return &logrus.TextFormatter{
+			ForceColors:   true,
+			FullTimestamp: true,
+		}
+	},
+	JsonLogFormat: func() logrus.Formatter {
+		return &logrus.JSONFormatter{}
+	},
+	// Logrus's text formatter emits logfmt when colors are disabled
+	LogfmtLogFormat: func() logrus.Formatter {
+		return &logrus.TextFormatter{
+			DisableColors: true,
+			FullTimestamp: true,
+		}
+	},
+}
+
+// A testsuite container only ever runs one test at a time, so the fields describing what's currently happening can
+//  live in a single process-wide hook
+var contextFields = &contextFieldsHook{
+	fields: logrus.Fields{},
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func SetLogFormat(logger *logrus.Logger, logFormat LogFormat) error {
+	formatterCreator, found := logFormatters[logFormat]
+	if !found {
+		allowedLogFormats := []string{}
+		for allowedLogFormat := range logFormatters {
+			allowedLogFormats = append(allowedLogFormats, string(allowedLogFormat))
+		}
+		sort.Strings(allowedLogFormats)
+		return stacktrace.NewError("Unrecognized log format '%v'; allowed log formats are %v", logFormat, allowedLogFormats)
+	}
+	logger.SetFormatter(formatterCreator())
+	return nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func AddContextFieldsHook(logger *logrus.Logger) {
+	logger.AddHook(contextFields)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func SetTestContext(testName string, phase TestPhase) {
+	fields := logrus.Fields{
+		TestPhaseLogField: phase,
+	}
+	// The suite-wide phases (e.g. BeforeAll) don't belong to any test
+	if testName != "" {
+		fields[TestNameLogField] = testName
+	}
+	contextFields.setFields(fields)
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func ClearTestContext() {
+	contextFields.setFields(logrus.Fields{})
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+// NOTE: Unlike the test context fields, the service ID is only on the entries logged through this
+func WithServiceID(serviceId services.ServiceID) *logrus.Entry {
+	return logrus.WithField(ServiceIdLogField, serviceId)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type contextFieldsHook struct {
+	mutex sync.RWMutex
+	fields logrus.Fields
+}
+
+func (hook *contextFieldsHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (hook *contextFieldsHook) Fire(entry *logrus.Entry) error {
+	hook.mutex.RLock()
+	defer hook.mutex.RUnlock()
+	for key, value := range hook.fields {
+		// Fields set explicitly on the entry win over the context
+		if _, found := entry.Data[key]; !found {
+			entry.Data[key] = value
+		}
+	}
+	return nil
+}
+
+func (hook *contextFieldsHook) setFields(fields logrus.Fields) {
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+	hook.fields = fields
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package metrics
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/grpc_method_names"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"google.golang.org/grpc"
+	"time"
+)
+
+const (
+	metricsNamespace = "kurtosis_testsuite"
+
+	testNameLabel = "test"
+	phaseLabel = "phase"
+	methodLabel = "method"
+
+	// The API container methods whose success changes the number of running services or the partitioning
+	startServiceMethodName = "StartService"
+	removeServiceMethodName = "RemoveService"
+	repartitionMethodName = "Repartition"
+)
+
+// We use our own registry rather than Prometheus's global one, so that testsuites' own metrics (if any) don't clash
+var registry = prometheus.NewRegistry()
+
+var (
+	testsStarted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name: "tests_started_total",
+			Help: "Number of tests whose setup was started",
+		},
+		[]string{testNameLabel},
+	)
+	testsPassed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name: "tests_passed_total",
+			Help: "Number of tests that were set up and run without error",
+		},
+		[]string{testNameLabel},
+	)
+	testsFailed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name: "tests_failed_total",
+			Help: "Number of tests whose setup or run returned an error",
+		},
+		[]string{testNameLabel},
+	)
+	phaseDurations = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name: "phase_duration_seconds",
+			Help: "Duration of the testsuite's phases (e.g. a test's setup)",
+			// Phases commonly take from under a second (e.g. a teardown) to tens of minutes (e.g. a soak test's run)
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 16),
+		},
+		[]string{phaseLabel},
+	)
+	apiContainerRpcDurations = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name: "api_container_rpc_duration_seconds",
+			Help: "Latency of the calls to the Kurtosis API container",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{methodLabel},
+	)
+	apiContainerRpcErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name: "api_container_rpc_errors_total",
+			Help: "Number of calls to the Kurtosis API container that returned an error",
+		},
+		[]string{methodLabel},
+	)
+	servicesRunning = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name: "services_running",
+			Help: "Number of services that the current test has started and not yet removed",
+		},
+	)
+	repartitions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name: "repartitions_total",
+			Help: "Number of times the test network was repartitioned",
+		},
+	)
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		testsStarted,
+		testsPassed,
+		testsFailed,
+		phaseDurations,
+		apiContainerRpcDurations,
+		apiContainerRpcErrors,
+		servicesRunning,
+		repartitions,
+	)
+}
+
+// Called when a test's setup starts
+func RecordTestStarted(testName string) {
+	testsStarted.WithLabelValues(testName).Inc()
+}
+
+// Called when a test fails during setup, or when its run finishes
+func RecordTestFinished(testName string, isPassed bool) {
+	if isPassed {
+		testsPassed.WithLabelValues(testName).Inc()
+	} else {
+		testsFailed.WithLabelValues(testName).Inc()
+	}
+	// Kurtosis destroys the test's network once the test is done without the services being removed through us, so
+	//  every service the test started is gone
+	servicesRunning.Set(0)
+}
+
+// Records the duration of a phase that started at the given time and has just finished
+func ObservePhaseDuration(phase logging.TestPhase, startTime time.Time) {
+	phaseDurations.WithLabelValues(string(phase)).Observe(time.Since(startTime).Seconds())
+}
+
+// Creates a gRPC client interceptor that records the latency and errors of each call to the Kurtosis API container,
+//  along with the effects of the calls on the test network
+func NewApiContainerClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+			ctx context.Context,
+			fullMethod string,
+			req interface{},
+			reply interface{},
+			conn *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption) error {
+		methodName := grpc_method_names.GetMethodName(fullMethod)
+
+		startTime := time.Now()
+		err := invoker(ctx, fullMethod, req, reply, conn, opts...)
+		apiContainerRpcDurations.WithLabelValues(methodName).Observe(time.Since(startTime).Seconds())
+		if err != nil {
+			apiContainerRpcErrors.WithLabelValues(methodName).Inc()
+			return err
+		}
+
+		switch methodName {
+		case startServiceMethodName:
+			servicesRunning.Inc()
+		case removeServiceMethodName:
+			servicesRunning.Dec()
+		case repartitionMethodName:
+			repartitions.Inc()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package metrics
+
+import (
+	"context"
+	"github.com/palantir/stacktrace"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
+	"net"
+	"net/http"
+)
+
+const (
+	metricsHttpPath = "/metrics"
+)
+
+// An HTTP server that serves the testsuite's metrics in the Prometheus exposition format
+type MetricsServer struct {
+	httpServer *http.Server
+
+	// Receives the result of serving once the server has stopped
+	serveResultChan chan error
+}
+
+// Starts serving the metrics in the background on the given 'address:port' (where port 0 means "pick a free port")
+func StartMetricsServer(listenAddress string) (*MetricsServer, error) {
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the metrics listener on '%v'", listenAddress)
+	}
+	logrus.Infof("Metrics server listening on address '%v'", listener.Addr().String())
+
+	mux := http.NewServeMux()
+	mux.Handle(metricsHttpPath, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	httpServer := &http.Server{Handler: mux}
+
+	serveResultChan := make(chan error, 1)
+	go func() {
+		var resultErr error = nil
+		if err := httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			resultErr = stacktrace.Propagate(err, "The metrics server exited with an error")
+		}
+		serveResultChan <- resultErr
+	}()
+
+	return &MetricsServer{httpServer: httpServer, serveResultChan: serveResultChan}, nil
+}
+
+// Stops the server, giving in-flight scrapes until the given context is done to finish
+func (server MetricsServer) Stop(ctx context.Context) error {
+	if err := server.httpServer.Shutdown(ctx); err != nil {
+		return stacktrace.Propagate(err, "An error occurred stopping the metrics server")
+	}
+	if err := <- server.serveResultChan; err != nil {
+		return stacktrace.Propagate(err, "The metrics server returned an error after it was done serving")
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_setup
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/palantir/stacktrace"
+	"sync"
+	"time"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func AddServices(
+		networkCtx *networks.NetworkContext,
+		configFactories map[services.ServiceID]services.ContainerConfigFactory,
+		timeBetweenPolls time.Duration,
+		timeout time.Duration) (map[services.ServiceID]services.Service, map[services.ServiceID]error, error) {
+	if timeBetweenPolls <= 0 {
+		return nil, nil, stacktrace.NewError("The time between polls must be positive, but was %v", timeBetweenPolls)
+	}
+	if timeout <= 0 {
+		return nil, nil, stacktrace.NewError("The timeout must be positive, but was %v", timeout)
+	}
+
+	// All services share the same deadline, so that a slow service doesn't get extra time just because it happened
+	//  to be registered later than the others
+	deadline := time.Now().Add(timeout)
+
+	resultsMutex := &sync.Mutex{}
+	startedServices := map[services.ServiceID]services.Service{}
+	failedServices := map[services.ServiceID]error{}
+
+	waitGroup := &sync.WaitGroup{}
+	for serviceId, configFactory := range configFactories {
+		waitGroup.Add(1)
+		go func(serviceId services.ServiceID, configFactory services.ContainerConfigFactory) {
+			defer waitGroup.Done()
+			service, err := addServiceAndWaitUntilDeadline(networkCtx, serviceId, configFactory, timeBetweenPolls, deadline)
+
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			if err != nil {
+				failedServices[serviceId] = err
+				return
+			}
+			startedServices[serviceId] = service
+		}(serviceId, configFactory)
+	}
+	waitGroup.Wait()
+
+	return startedServices, failedServices, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func addServiceAndWaitUntilDeadline(
+		networkCtx *networks.NetworkContext,
+		serviceId services.ServiceID,
+		configFactory services.ContainerConfigFactory,
+		timeBetweenPolls time.Duration,
+		deadline time.Time) (services.Service, error) {
+	logging.WithServiceID(serviceId).Debug("Adding service...")
+	service, hostPortBindings, checker, err := networkCtx.AddService(serviceId, configFactory)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred adding service '%v'", serviceId)
+	}
+
+	maxNumPolls, err := getMaxNumPollsBeforeDeadline(time.Now(), deadline, timeBetweenPolls)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting how many times to poll service '%v' for availability", serviceId)
+	}
+	if err := checker.WaitForStartup(timeBetweenPolls, maxNumPolls); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred waiting for service '%v' to become available", serviceId)
+	}
+	logging.WithServiceID(serviceId).Infof("Added service with host port bindings: %+v", hostPortBindings)
+	return service, nil
+}
+
+// The availability checker only knows about polls, so we translate whatever is left of the deadline into a number of
+//  polls; a service that's added with less than one poll's worth of time left still gets a single poll
+func getMaxNumPollsBeforeDeadline(now time.Time, deadline time.Time, timeBetweenPolls time.Duration) (int, error) {
+	remainingTime := deadline.Sub(now)
+	if remainingTime <= 0 {
+		return 0, stacktrace.NewError("The deadline passed %v ago, while the service was being added", -remainingTime)
+	}
+	maxNumPolls := int(remainingTime / timeBetweenPolls)
+	if maxNumPolls < 1 {
+		maxNumPolls = 1
+	}
+	return maxNumPolls, nil
+}
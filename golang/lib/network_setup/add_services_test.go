@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_setup
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"testing"
+	"time"
+)
+
+func TestAddServicesValidatesArgs(t *testing.T) {
+	testCases := []struct {
+		name string
+		timeBetweenPolls time.Duration
+		timeout time.Duration
+		expectErr bool
+	}{
+		{name: "valid", timeBetweenPolls: time.Second, timeout: 10 * time.Second},
+		{name: "zero time between polls", timeBetweenPolls: 0, timeout: 10 * time.Second, expectErr: true},
+		{name: "negative time between polls", timeBetweenPolls: -time.Second, timeout: 10 * time.Second, expectErr: true},
+		{name: "zero timeout", timeBetweenPolls: time.Second, timeout: 0, expectErr: true},
+		{name: "negative timeout", timeBetweenPolls: time.Second, timeout: -time.Second, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// No config factories, so the network is never used
+			_, _, err := AddServices(nil, map[services.ServiceID]services.ContainerConfigFactory{}, testCase.timeBetweenPolls, testCase.timeout)
+			if testCase.expectErr && err == nil {
+				t.Fatalf("Expected an error, but got none")
+			}
+			if !testCase.expectErr && err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetMaxNumPollsBeforeDeadline(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name string
+		remainingTime time.Duration
+		timeBetweenPolls time.Duration
+		// Only checked if no error is expected
+		expectedMaxNumPolls int
+		expectErr bool
+	}{
+		{name: "whole number of polls", remainingTime: 10 * time.Second, timeBetweenPolls: time.Second, expectedMaxNumPolls: 10},
+		{name: "partial poll is dropped", remainingTime: 10500 * time.Millisecond, timeBetweenPolls: time.Second, expectedMaxNumPolls: 10},
+		{name: "less than one poll left", remainingTime: 500 * time.Millisecond, timeBetweenPolls: time.Second, expectedMaxNumPolls: 1},
+		{name: "poll longer than the whole timeout", remainingTime: time.Nanosecond, timeBetweenPolls: time.Hour, expectedMaxNumPolls: 1},
+		{name: "deadline is now", remainingTime: 0, timeBetweenPolls: time.Second, expectErr: true},
+		{name: "deadline has passed", remainingTime: -time.Second, timeBetweenPolls: time.Second, expectErr: true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			maxNumPolls, err := getMaxNumPollsBeforeDeadline(now, now.Add(testCase.remainingTime), testCase.timeBetweenPolls)
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, but got %v polls", maxNumPolls)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if maxNumPolls != testCase.expectedMaxNumPolls {
+				t.Fatalf("Expected %v polls, but got %v", testCase.expectedMaxNumPolls, maxNumPolls)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_setup
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/dependency_graph"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/palantir/stacktrace"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+	defaultWaitForStartupTimeBetweenPolls = 1 * time.Second
+	defaultWaitForStartupMaxNumPolls = 15
+	// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type ConfigFactoryCreator func(dependencies map[services.ServiceID]services.Service) (services.ContainerConfigFactory, error)
+
+type serviceDefinition struct {
+	configFactoryCreator ConfigFactoryCreator
+	dependencies []services.ServiceID
+}
+
+// The result of starting a single service, which dependents block on
+type serviceStartResult struct {
+	// Closed when the service has either become available or failed
+	done chan struct{}
+
+	service services.Service
+
+	// Will be non-nil if the service failed to start, or was never started because a dependency failed
+	err error
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type NetworkBuilder struct {
+	networkCtx *networks.NetworkContext
+	serviceDefinitions map[services.ServiceID]*serviceDefinition
+
+	// IDs that WithService was called with more than once, which are reported by Build rather than silently replaced
+	duplicateServiceIds map[services.ServiceID]bool
+
+	waitForStartupTimeBetweenPolls time.Duration
+	waitForStartupMaxNumPolls int
+}
+
+func NewNetworkBuilder(networkCtx *networks.NetworkContext) *NetworkBuilder {
+	return &NetworkBuilder{
+		networkCtx:                     networkCtx,
+		serviceDefinitions:             map[services.ServiceID]*serviceDefinition{},
+		duplicateServiceIds:            map[services.ServiceID]bool{},
+		waitForStartupTimeBetweenPolls: defaultWaitForStartupTimeBetweenPolls,
+		waitForStartupMaxNumPolls:      defaultWaitForStartupMaxNumPolls,
+	}
+}
+
+func (builder *NetworkBuilder) WithService(
+		serviceId services.ServiceID,
+		configFactoryCreator ConfigFactoryCreator,
+		dependencies ...services.ServiceID) *NetworkBuilder {
+	if _, found := builder.serviceDefinitions[serviceId]; found {
+		builder.duplicateServiceIds[serviceId] = true
+	}
+	builder.serviceDefinitions[serviceId] = &serviceDefinition{
+		configFactoryCreator: configFactoryCreator,
+		dependencies:         dependencies,
+	}
+	return builder
+}
+
+func (builder *NetworkBuilder) WithWaitForStartupPolling(timeBetweenPolls time.Duration, maxNumPolls int) *NetworkBuilder {
+	builder.waitForStartupTimeBetweenPolls = timeBetweenPolls
+	builder.waitForStartupMaxNumPolls = maxNumPolls
+	return builder
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func (builder NetworkBuilder) Build() (map[services.ServiceID]services.Service, error) {
+	if err := builder.validateDependencyGraph(); err != nil {
+		return nil, stacktrace.Propagate(err, "The service dependency graph is invalid")
+	}
+
+	startResults := map[services.ServiceID]*serviceStartResult{}
+	for serviceId := range builder.serviceDefinitions {
+		startResults[serviceId] = &serviceStartResult{
+			done: make(chan struct{}),
+		}
+	}
+
+	// Every service gets its own goroutine that blocks until its dependencies are available, so that services
+	//  without a dependency relationship start in parallel
+	waitGroup := &sync.WaitGroup{}
+	for serviceId, definition := range builder.serviceDefinitions {
+		waitGroup.Add(1)
+		go func(serviceId services.ServiceID, definition *serviceDefinition) {
+			defer waitGroup.Done()
+			result := startResults[serviceId]
+			defer close(result.done)
+			result.service, result.err = builder.startServiceAfterDependencies(serviceId, definition, startResults)
+		}(serviceId, definition)
+	}
+	waitGroup.Wait()
+
+	startedServices := map[services.ServiceID]services.Service{}
+	errorStrs := []string{}
+	for serviceId, result := range startResults {
+		if result.err != nil {
+			errorStrs = append(errorStrs, fmt.Sprintf("Service '%v': %v", serviceId, result.err.Error()))
+			continue
+		}
+		startedServices[serviceId] = result.service
+	}
+	if len(errorStrs) > 0 {
+		sort.Strings(errorStrs)
+		return nil, stacktrace.NewError(
+			"One or more services failed to start:\n%v",
+			strings.Join(errorStrs, "\n"),
+		)
+	}
+	return startedServices, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (builder NetworkBuilder) startServiceAfterDependencies(
+		serviceId services.ServiceID,
+		definition *serviceDefinition,
+		startResults map[services.ServiceID]*serviceStartResult) (services.Service, error) {
+	dependencies := map[services.ServiceID]services.Service{}
+	for _, dependencyId := range definition.dependencies {
+		dependencyResult := startResults[dependencyId]
+		<-dependencyResult.done
+		if dependencyResult.err != nil {
+			return nil, stacktrace.NewError("Service wasn't started because its dependency '%v' failed to start", dependencyId)
+		}
+		dependencies[dependencyId] = dependencyResult.service
+	}
+
+	configFactory, err := definition.configFactoryCreator(dependencies)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the config factory for service '%v'", serviceId)
+	}
+
+	logging.WithServiceID(serviceId).Debug("Adding service...")
+	service, hostPortBindings, checker, err := builder.networkCtx.AddService(serviceId, configFactory)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred adding service '%v'", serviceId)
+	}
+	if err := checker.WaitForStartup(builder.waitForStartupTimeBetweenPolls, builder.waitForStartupMaxNumPolls); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred waiting for service '%v' to become available", serviceId)
+	}
+	logging.WithServiceID(serviceId).Infof("Added service with host port bindings: %+v", hostPortBindings)
+	return service, nil
+}
+
+// Verifies that every service was declared once, that every dependency refers to a declared service, and that there
+//  are no dependency cycles
+func (builder NetworkBuilder) validateDependencyGraph() error {
+	if len(builder.duplicateServiceIds) > 0 {
+		duplicateServiceIdStrs := []string{}
+		for serviceId := range builder.duplicateServiceIds {
+			duplicateServiceIdStrs = append(duplicateServiceIdStrs, string(serviceId))
+		}
+		sort.Strings(duplicateServiceIdStrs)
+		return stacktrace.NewError(
+			"The following service IDs were declared more than once: %v",
+			strings.Join(duplicateServiceIdStrs, ", "),
+		)
+	}
+
+	for serviceId, definition := range builder.serviceDefinitions {
+		for _, dependencyId := range definition.dependencies {
+			if _, found := builder.serviceDefinitions[dependencyId]; !found {
+				return stacktrace.NewError(
+					"Service '%v' depends on service '%v', but no service with that ID was declared",
+					serviceId,
+					dependencyId,
+				)
+			}
+		}
+	}
+
+	dependencyGraph := map[string]map[string]bool{}
+	for serviceId, definition := range builder.serviceDefinitions {
+		dependencyIdStrs := map[string]bool{}
+		for _, dependencyId := range definition.dependencies {
+			dependencyIdStrs[string(dependencyId)] = true
+		}
+		dependencyGraph[string(serviceId)] = dependencyIdStrs
+	}
+	if cycle := dependency_graph.FindCycle(dependencyGraph); cycle != nil {
+		return stacktrace.NewError("Found a service dependency cycle: %v", strings.Join(cycle, " -> "))
+	}
+	return nil
+}
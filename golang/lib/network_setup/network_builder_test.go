@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_setup
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"strings"
+	"testing"
+)
+
+// Never called, as validation doesn't start any services
+func noopConfigFactoryCreator(dependencies map[services.ServiceID]services.Service) (services.ContainerConfigFactory, error) {
+	return nil, nil
+}
+
+type testServiceDeclaration struct {
+	serviceId services.ServiceID
+	dependencies []services.ServiceID
+}
+
+func TestValidateDependencyGraph(t *testing.T) {
+	testCases := []struct {
+		name string
+		declarations []testServiceDeclaration
+		// Empty if the graph should be valid
+		expectedErrSubstring string
+	}{
+		{
+			name: "no services",
+			declarations: []testServiceDeclaration{},
+		},
+		{
+			name: "chain and diamond",
+			declarations: []testServiceDeclaration{
+				{serviceId: "datastore"},
+				{serviceId: "cache"},
+				{serviceId: "api", dependencies: []services.ServiceID{"datastore", "cache"}},
+				{serviceId: "frontend", dependencies: []services.ServiceID{"api", "cache"}},
+			},
+		},
+		{
+			name: "self dependency",
+			declarations: []testServiceDeclaration{
+				{serviceId: "api", dependencies: []services.ServiceID{"api"}},
+			},
+			expectedErrSubstring: "Found a service dependency cycle: api -> api",
+		},
+		{
+			name: "two-service cycle",
+			declarations: []testServiceDeclaration{
+				{serviceId: "a", dependencies: []services.ServiceID{"b"}},
+				{serviceId: "b", dependencies: []services.ServiceID{"a"}},
+			},
+			expectedErrSubstring: "Found a service dependency cycle: a -> b -> a",
+		},
+		{
+			name: "cycle is reported without the path leading into it",
+			declarations: []testServiceDeclaration{
+				{serviceId: "a", dependencies: []services.ServiceID{"b"}},
+				{serviceId: "b", dependencies: []services.ServiceID{"c"}},
+				{serviceId: "c", dependencies: []services.ServiceID{"d"}},
+				{serviceId: "d", dependencies: []services.ServiceID{"b"}},
+			},
+			expectedErrSubstring: "Found a service dependency cycle: b -> c -> d -> b",
+		},
+		{
+			name: "undeclared dependency",
+			declarations: []testServiceDeclaration{
+				{serviceId: "api", dependencies: []services.ServiceID{"datastore"}},
+			},
+			expectedErrSubstring: "Service 'api' depends on service 'datastore', but no service with that ID was declared",
+		},
+		{
+			name: "duplicate declarations",
+			declarations: []testServiceDeclaration{
+				{serviceId: "b"},
+				{serviceId: "a"},
+				{serviceId: "b"},
+				{serviceId: "a"},
+			},
+			expectedErrSubstring: "The following service IDs were declared more than once: a, b",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			builder := NewNetworkBuilder(nil)
+			for _, declaration := range testCase.declarations {
+				builder.WithService(declaration.serviceId, noopConfigFactoryCreator, declaration.dependencies...)
+			}
+			err := builder.validateDependencyGraph()
+			if testCase.expectedErrSubstring == "" {
+				if err != nil {
+					t.Fatalf("Expected the graph to be valid, but got error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Expected an error containing '%v', but got none", testCase.expectedErrSubstring)
+			}
+			if !strings.Contains(err.Error(), testCase.expectedErrSubstring) {
+				t.Fatalf("Expected an error containing '%v', but got: %v", testCase.expectedErrSubstring, err)
+			}
+		})
+	}
+}
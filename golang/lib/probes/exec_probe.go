@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type ExecProbe struct {
+	command []string
+	expectedExitCode int32
+	timeout time.Duration
+}
+
+func NewExecProbe(command []string, expectedExitCode int32) *ExecProbe {
+	return &ExecProbe{
+		command:          command,
+		expectedExitCode: expectedExitCode,
+		timeout:          defaultProbeTimeout,
+	}
+}
+
+func (probe *ExecProbe) WithTimeout(timeout time.Duration) *ExecProbe {
+	probe.timeout = timeout
+	return probe
+}
+
+func (probe ExecProbe) GetDescription() string {
+	return fmt.Sprintf("Exec of command '%v'", probe.command)
+}
+
+func (probe ExecProbe) Check(serviceCtx *services.ServiceContext) error {
+	exitCode, logOutput, err := execCommandWithTimeout(serviceCtx, probe.command, probe.timeout)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred executing command '%v'", probe.command)
+	}
+	if exitCode != probe.expectedExitCode {
+		logOutputStr := ""
+		if logOutput != nil {
+			logOutputStr = string(*logOutput)
+		}
+		return stacktrace.NewError(
+			"Expected exit code %v but got %v, with log output:\n%v",
+			probe.expectedExitCode,
+			exitCode,
+			logOutputStr,
+		)
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type execCommandResult struct {
+	exitCode int32
+	logOutput *[]byte
+	err error
+}
+
+// ServiceContext.ExecCommand can't be cancelled, so a command that times out is left running in the background with its
+//  result discarded; this at least stops a hung command from blocking the availability poll forever
+func execCommandWithTimeout(serviceCtx *services.ServiceContext, command []string, timeout time.Duration) (int32, *[]byte, error) {
+	return runExecWithTimeout(
+		func() (int32, *[]byte, error) {
+			return serviceCtx.ExecCommand(command)
+		},
+		timeout,
+	)
+}
+
+func runExecWithTimeout(execFunc func() (int32, *[]byte, error), timeout time.Duration) (int32, *[]byte, error) {
+	// Buffered so that the goroutine can still exit if we stop waiting for it
+	resultChan := make(chan execCommandResult, 1)
+	go func() {
+		exitCode, logOutput, err := execFunc()
+		resultChan <- execCommandResult{exitCode: exitCode, logOutput: logOutput, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case result := <-resultChan:
+		return result.exitCode, result.logOutput, result.err
+	case <-timer.C:
+		return 0, nil, stacktrace.NewError("The command didn't finish within %v", timeout)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testExecTimeout = 50 * time.Millisecond
+)
+
+func TestRunExecWithTimeout(t *testing.T) {
+	logOutput := []byte("output")
+
+	testCases := []struct {
+		name string
+		execFunc func() (int32, *[]byte, error)
+		expectedExitCode int32
+		expectedLogOutput *[]byte
+		// Must appear in the error; empty if no error is expected
+		expectedErrSubstring string
+	}{
+		{
+			name: "finishes in time",
+			execFunc: func() (int32, *[]byte, error) { return 3, &logOutput, nil },
+			expectedExitCode: 3,
+			expectedLogOutput: &logOutput,
+		},
+		{
+			name: "returns error in time",
+			execFunc: func() (int32, *[]byte, error) { return 0, nil, errors.New("exec failed") },
+			expectedErrSubstring: "exec failed",
+		},
+		{
+			name: "hangs",
+			execFunc: func() (int32, *[]byte, error) {
+				time.Sleep(time.Hour)
+				return 0, nil, nil
+			},
+			expectedErrSubstring: "didn't finish within",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			startTime := time.Now()
+			exitCode, actualLogOutput, err := runExecWithTimeout(testCase.execFunc, testExecTimeout)
+			// Generous, so that a slow CI machine doesn't make this flaky
+			if elapsed := time.Since(startTime); elapsed > 20 * testExecTimeout {
+				t.Fatalf("Expected to return within about %v, but took %v", testExecTimeout, elapsed)
+			}
+
+			if testCase.expectedErrSubstring != "" {
+				if err == nil {
+					t.Fatalf("Expected an error containing '%v', but got none", testCase.expectedErrSubstring)
+				}
+				if !strings.Contains(err.Error(), testCase.expectedErrSubstring) {
+					t.Fatalf("Expected the error to contain '%v', but got: %v", testCase.expectedErrSubstring, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if exitCode != testCase.expectedExitCode {
+				t.Fatalf("Expected exit code %v, but got %v", testCase.expectedExitCode, exitCode)
+			}
+			if actualLogOutput != testCase.expectedLogOutput {
+				t.Fatalf("Expected log output %v, but got %v", testCase.expectedLogOutput, actualLogOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"time"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type GrpcHealthProbe struct {
+	port int
+
+	// Emptystring checks the health of the server as a whole
+	grpcServiceName string
+
+	timeout time.Duration
+}
+
+func NewGrpcHealthProbe(port int, grpcServiceName string) *GrpcHealthProbe {
+	return &GrpcHealthProbe{
+		port:            port,
+		grpcServiceName: grpcServiceName,
+		timeout:         defaultProbeTimeout,
+	}
+}
+
+func (probe *GrpcHealthProbe) WithTimeout(timeout time.Duration) *GrpcHealthProbe {
+	probe.timeout = timeout
+	return probe
+}
+
+func (probe GrpcHealthProbe) GetDescription() string {
+	return fmt.Sprintf("gRPC health check on port %v for service '%v'", probe.port, probe.grpcServiceName)
+}
+
+func (probe GrpcHealthProbe) Check(serviceCtx *services.ServiceContext) error {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), probe.timeout)
+	defer cancelFunc()
+
+	address := fmt.Sprintf("%v:%v", serviceCtx.GetIPAddress(), probe.port)
+	conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred connecting to the gRPC server at '%v'", address)
+	}
+	defer conn.Close()
+
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+	resp, err := healthClient.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: probe.grpcServiceName})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making the health check request to '%v'", address)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return stacktrace.NewError("Expected health status '%v' but got '%v'", grpc_health_v1.HealthCheckResponse_SERVING, resp.Status)
+	}
+	return nil
+}
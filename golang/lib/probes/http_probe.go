@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type HttpProbe struct {
+	port int
+	urlPath string
+	expectedStatusCode int
+
+	// Emptystring means that any body is accepted
+	expectedBody string
+
+	timeout time.Duration
+}
+
+func NewHttpProbe(port int, urlPath string, expectedStatusCode int, expectedBody string) *HttpProbe {
+	return &HttpProbe{
+		port:               port,
+		urlPath:            strings.TrimPrefix(urlPath, "/"),
+		expectedStatusCode: expectedStatusCode,
+		expectedBody:       expectedBody,
+		timeout:            defaultProbeTimeout,
+	}
+}
+
+func (probe *HttpProbe) WithTimeout(timeout time.Duration) *HttpProbe {
+	probe.timeout = timeout
+	return probe
+}
+
+func (probe HttpProbe) GetDescription() string {
+	return fmt.Sprintf("HTTP GET on port %v at path '/%v'", probe.port, probe.urlPath)
+}
+
+func (probe HttpProbe) Check(serviceCtx *services.ServiceContext) error {
+	url := fmt.Sprintf("http://%v:%v/%v", serviceCtx.GetIPAddress(), probe.port, probe.urlPath)
+	client := &http.Client{Timeout: probe.timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making the request to '%v'", url)
+	}
+	body := resp.Body
+	defer body.Close()
+
+	if resp.StatusCode != probe.expectedStatusCode {
+		return stacktrace.NewError("Expected status code %v but got %v", probe.expectedStatusCode, resp.StatusCode)
+	}
+	if probe.expectedBody == "" {
+		return nil
+	}
+
+	bodyBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the response body")
+	}
+	bodyStr := string(bodyBytes)
+	if bodyStr != probe.expectedBody {
+		return stacktrace.NewError("Expected response body '%v' but got '%v'", probe.expectedBody, bodyStr)
+	}
+	return nil
+}
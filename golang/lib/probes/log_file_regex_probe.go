@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"regexp"
+	"time"
+)
+
+const (
+	catCommand = "cat"
+
+	successExitCode int32 = 0
+)
+
+/*
+Passes when the contents of a log file inside the service's container match a regex (use the (?m) flag to anchor on lines)
+
+NOTE: Kurtosis doesn't yet expose a container's stdout/stderr to the testsuite, so this probe can only check log files
+	that the service writes to its filesystem
+ */
+type LogFileRegexProbe struct {
+	logFilepath string
+	pattern *regexp.Regexp
+	timeout time.Duration
+}
+
+func NewLogFileRegexProbe(logFilepath string, pattern *regexp.Regexp) *LogFileRegexProbe {
+	return &LogFileRegexProbe{
+		logFilepath: logFilepath,
+		pattern:     pattern,
+		timeout:     defaultProbeTimeout,
+	}
+}
+
+func (probe *LogFileRegexProbe) WithTimeout(timeout time.Duration) *LogFileRegexProbe {
+	probe.timeout = timeout
+	return probe
+}
+
+func (probe LogFileRegexProbe) GetDescription() string {
+	return fmt.Sprintf("Log file '%v' matching regex '%v'", probe.logFilepath, probe.pattern.String())
+}
+
+func (probe LogFileRegexProbe) Check(serviceCtx *services.ServiceContext) error {
+	command := []string{catCommand, probe.logFilepath}
+	exitCode, logOutput, err := execCommandWithTimeout(serviceCtx, command, probe.timeout)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading log file '%v'", probe.logFilepath)
+	}
+	if exitCode != successExitCode {
+		return stacktrace.NewError("Reading log file '%v' returned non-zero exit code %v", probe.logFilepath, exitCode)
+	}
+	if logOutput == nil || !probe.pattern.Match(*logOutput) {
+		return stacktrace.NewError("The contents of log file '%v' didn't match regex '%v'", probe.logFilepath, probe.pattern.String())
+	}
+	return nil
+}
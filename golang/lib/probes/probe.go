@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/palantir/stacktrace"
+	"strings"
+	"time"
+)
+
+const (
+	// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+	defaultProbeTimeout = 5 * time.Second
+	// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type Probe interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	GetDescription() string
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Check(serviceCtx *services.ServiceContext) error
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func CheckAll(serviceCtx *services.ServiceContext, probes ...Probe) error {
+	failureStrs := []string{}
+	for _, probe := range probes {
+		if err := probe.Check(serviceCtx); err != nil {
+			failureStrs = append(failureStrs, fmt.Sprintf("%v: %v", probe.GetDescription(), err.Error()))
+		}
+	}
+	if len(failureStrs) > 0 {
+		return stacktrace.NewError(
+			"%v of %v probes failed for service '%v':\n%v",
+			len(failureStrs),
+			len(probes),
+			serviceCtx.GetServiceID(),
+			strings.Join(failureStrs, "\n"),
+		)
+	}
+	return nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func IsAvailable(serviceCtx *services.ServiceContext, probes ...Probe) bool {
+	if err := CheckAll(serviceCtx, probes...); err != nil {
+		logging.WithServiceID(serviceCtx.GetServiceID()).Debugf("Service isn't available yet: %v", err)
+		return false
+	}
+	return true
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func WaitForProbes(serviceCtx *services.ServiceContext, timeBetweenPolls time.Duration, timeout time.Duration, probes ...Probe) error {
+	deadline := time.Now().Add(timeout)
+	numPolls := 0
+	for {
+		numPolls++
+		lastErr := CheckAll(serviceCtx, probes...)
+		if lastErr == nil {
+			return nil
+		}
+		if time.Now().Add(timeBetweenPolls).After(deadline) {
+			return stacktrace.Propagate(
+				lastErr,
+				"Service '%v' didn't become available within %v (%v polls); the last poll's failures are included",
+				serviceCtx.GetServiceID(),
+				timeout,
+				numPolls,
+			)
+		}
+		time.Sleep(timeBetweenPolls)
+	}
+}
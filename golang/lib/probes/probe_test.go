@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testServiceId services.ServiceID = "test-service"
+	testServiceIpAddress = "1.2.3.4"
+
+	testTimeBetweenPolls = 10 * time.Millisecond
+)
+
+// Fails with a message containing the number of times it's been checked, until it's been checked numFailures times
+type countingProbe struct {
+	description string
+	numFailures int
+	numChecks int
+}
+
+func (probe *countingProbe) GetDescription() string {
+	return probe.description
+}
+
+func (probe *countingProbe) Check(serviceCtx *services.ServiceContext) error {
+	probe.numChecks++
+	if probe.numChecks <= probe.numFailures {
+		return fmt.Errorf("failure on check %v", probe.numChecks)
+	}
+	return nil
+}
+
+func newAlwaysPassingProbe(description string) *countingProbe {
+	return &countingProbe{description: description, numFailures: 0}
+}
+
+func newAlwaysFailingProbe(description string) *countingProbe {
+	// Large enough that it never passes within a test
+	return &countingProbe{description: description, numFailures: 1000000}
+}
+
+func TestCheckAll(t *testing.T) {
+	testCases := []struct {
+		name string
+		probes []Probe
+		// Each of these must appear in the error; empty if all the probes should pass
+		expectedErrSubstrings []string
+		// None of these may appear in the error
+		unexpectedErrSubstrings []string
+	}{
+		{
+			name: "no probes",
+			probes: []Probe{},
+		},
+		{
+			name: "all pass",
+			probes: []Probe{newAlwaysPassingProbe("first"), newAlwaysPassingProbe("second")},
+		},
+		{
+			name: "one of several fails",
+			probes: []Probe{newAlwaysPassingProbe("first"), newAlwaysFailingProbe("second"), newAlwaysPassingProbe("third")},
+			expectedErrSubstrings: []string{"1 of 3 probes failed", "second: failure on check 1"},
+			unexpectedErrSubstrings: []string{"first", "third"},
+		},
+		{
+			name: "every failure is reported",
+			probes: []Probe{newAlwaysFailingProbe("first"), newAlwaysPassingProbe("second"), newAlwaysFailingProbe("third")},
+			expectedErrSubstrings: []string{"2 of 3 probes failed", "first: failure on check 1", "third: failure on check 1"},
+			unexpectedErrSubstrings: []string{"second"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := CheckAll(newTestServiceContext(), testCase.probes...)
+			assertErrSubstrings(t, err, testCase.expectedErrSubstrings, testCase.unexpectedErrSubstrings)
+		})
+	}
+}
+
+func TestCheckAllRunsEveryProbe(t *testing.T) {
+	probes := []*countingProbe{newAlwaysFailingProbe("first"), newAlwaysFailingProbe("second"), newAlwaysPassingProbe("third")}
+	if err := CheckAll(newTestServiceContext(), probes[0], probes[1], probes[2]); err == nil {
+		t.Fatalf("Expected an error, but got none")
+	}
+	for _, probe := range probes {
+		if probe.numChecks != 1 {
+			t.Fatalf("Expected probe '%v' to be checked once, but it was checked %v times", probe.description, probe.numChecks)
+		}
+	}
+}
+
+func TestWaitForProbes(t *testing.T) {
+	testCases := []struct {
+		name string
+		probes []Probe
+		timeout time.Duration
+		// Each of these must appear in the error; empty if the probes should pass before the timeout
+		expectedErrSubstrings []string
+		// None of these may appear in the error
+		unexpectedErrSubstrings []string
+	}{
+		{
+			name: "passes immediately",
+			probes: []Probe{newAlwaysPassingProbe("first")},
+			timeout: time.Second,
+		},
+		{
+			name: "passes after some failed polls",
+			probes: []Probe{&countingProbe{description: "first", numFailures: 3}, newAlwaysPassingProbe("second")},
+			timeout: 5 * time.Second,
+		},
+		{
+			name: "timeout shorter than one poll still polls once",
+			probes: []Probe{newAlwaysFailingProbe("first")},
+			timeout: time.Nanosecond,
+			expectedErrSubstrings: []string{"(1 polls)", "first: failure on check 1"},
+		},
+		{
+			name: "only the last poll's failures are reported",
+			probes: []Probe{newAlwaysFailingProbe("first"), &countingProbe{description: "second", numFailures: 1}},
+			timeout: 10 * testTimeBetweenPolls,
+			expectedErrSubstrings: []string{"1 of 2 probes failed", "first: failure on check"},
+			// The second probe's earlier failures must not leak into the diagnostics
+			unexpectedErrSubstrings: []string{"second"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := WaitForProbes(newTestServiceContext(), testTimeBetweenPolls, testCase.timeout, testCase.probes...)
+			assertErrSubstrings(t, err, testCase.expectedErrSubstrings, testCase.unexpectedErrSubstrings)
+		})
+	}
+}
+
+func TestWaitForProbesReportsNumPolls(t *testing.T) {
+	probe := newAlwaysFailingProbe("first")
+	err := WaitForProbes(newTestServiceContext(), testTimeBetweenPolls, 5 * testTimeBetweenPolls, probe)
+	if err == nil {
+		t.Fatalf("Expected an error, but got none")
+	}
+	// The failure reported must be from the last poll, and the poll count must match the number of checks
+	expectedErrSubstrings := []string{
+		fmt.Sprintf("(%v polls)", probe.numChecks),
+		fmt.Sprintf("first: failure on check %v", probe.numChecks),
+	}
+	assertErrSubstrings(t, err, expectedErrSubstrings, []string{})
+	if probe.numChecks < 2 {
+		t.Fatalf("Expected the probe to be polled more than once, but it was polled %v times", probe.numChecks)
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func newTestServiceContext() *services.ServiceContext {
+	// The probes in these tests never talk to the API container, so no client is needed
+	return services.NewServiceContext(nil, testServiceId, testServiceIpAddress)
+}
+
+func assertErrSubstrings(t *testing.T, err error, expectedErrSubstrings []string, unexpectedErrSubstrings []string) {
+	if len(expectedErrSubstrings) == 0 {
+		if err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("Expected an error, but got none")
+	}
+	for _, substring := range expectedErrSubstrings {
+		if !strings.Contains(err.Error(), substring) {
+			t.Errorf("Expected the error to contain '%v', but got: %v", substring, err)
+		}
+	}
+	for _, substring := range unexpectedErrSubstrings {
+		if strings.Contains(err.Error(), substring) {
+			t.Errorf("Expected the error not to contain '%v', but got: %v", substring, err)
+		}
+	}
+}
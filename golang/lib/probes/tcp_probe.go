@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package probes
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/palantir/stacktrace"
+	"net"
+	"time"
+)
+
+const (
+	tcpNetwork = "tcp"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type TcpProbe struct {
+	port int
+	timeout time.Duration
+}
+
+func NewTcpProbe(port int) *TcpProbe {
+	return &TcpProbe{
+		port:    port,
+		timeout: defaultProbeTimeout,
+	}
+}
+
+func (probe *TcpProbe) WithTimeout(timeout time.Duration) *TcpProbe {
+	probe.timeout = timeout
+	return probe
+}
+
+func (probe TcpProbe) GetDescription() string {
+	return fmt.Sprintf("TCP connect on port %v", probe.port)
+}
+
+func (probe TcpProbe) Check(serviceCtx *services.ServiceContext) error {
+	address := fmt.Sprintf("%v:%v", serviceCtx.GetIPAddress(), probe.port)
+	conn, err := net.DialTimeout(tcpNetwork, address, probe.timeout)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening a TCP connection to '%v'", address)
+	}
+	conn.Close()
+	return nil
+}
@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package grpc_method_names
+
+import (
+	"strings"
+)
+
+const (
+	fullMethodSeparator = "/"
+)
+
+// Gets the bare method name (e.g. 'StartService') from a gRPC full method, which is of the form '/package.Service/Method'
+func GetMethodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, fullMethodSeparator)+1:]
+}
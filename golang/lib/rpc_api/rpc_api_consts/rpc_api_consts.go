@@ -3,4 +3,30 @@ package rpc_api_consts
 const (
 	ListenProtocol = "tcp"
 	ListenPort = 7718
+
+	// Version of the suite API (suite-api/test_suite_service.proto) that this library implements
+	// This should be bumped on every change to the suite API: the major version for breaking changes, and the minor
+	//  version for backwards-compatible additions
+	SuiteApiVersion = "1.0.0"
+
+	// NOTE: This is updated automatically by the release script, so shouldn't be modified by hand
+	LibVersion = "1.25.0"
+)
+
+// vvvvvvvvv Update the docs if you change these vvvvvvvvvvv
+// Optional features that the testsuite reports supporting in its metadata
+const (
+	// The testsuite interrupts the running test and runs its teardown when sent a termination signal, so callers
+	//  should prefer stopping the testsuite container gracefully
+	GracefulShutdownCapability = "graceful-shutdown"
+
+	// The testsuite's metadata declares test dependencies, test priorities, and a fail-fast policy
+	TestDependenciesCapability = "test-dependencies"
+
+	// The testsuite serves the grpc.health.v1 health service
+	GrpcHealthCapability = "grpc-health"
+
+	// The testsuite serves gRPC server reflection
+	ServerReflectionCapability = "server-reflection"
 )
+// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package rpc_security
+
+import (
+	"crypto/tls"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+/*
+Configures how the testsuite secures its connection to the Kurtosis API container
+
+The Kurtosis API container serves plaintext, so an empty config (the default) results in an insecure connection with
+	no auth token. Every setting is optional:
+ - If a CA cert is provided, TLS is used and the API container's cert is verified against the CA
+ - If a client cert and key are provided, TLS is used and they're presented to the API container, for mutual TLS
+ - If an auth token is provided, it's sent as a bearer token on every call to the API container
+*/
+type ApiContainerSecurityConfig struct {
+	caCertFilepath string
+	clientCertFilepath string
+	clientKeyFilepath string
+	authTokenFilepath string
+}
+
+func NewApiContainerSecurityConfig(caCertFilepath string, clientCertFilepath string, clientKeyFilepath string, authTokenFilepath string) *ApiContainerSecurityConfig {
+	return &ApiContainerSecurityConfig{caCertFilepath: caCertFilepath, clientCertFilepath: clientCertFilepath, clientKeyFilepath: clientKeyFilepath, authTokenFilepath: authTokenFilepath}
+}
+
+func (config ApiContainerSecurityConfig) GetDialOptions() ([]grpc.DialOption, error) {
+	result := []grpc.DialOption{}
+
+	isClientCertProvided := config.clientCertFilepath != "" || config.clientKeyFilepath != ""
+	if config.caCertFilepath == "" && !isClientCertProvided {
+		result = append(result, grpc.WithInsecure())
+	} else {
+		// If no CA cert is provided, the API container's cert is verified against the system roots
+		tlsConfig := &tls.Config{}
+		if config.caCertFilepath != "" {
+			caCertPool, err := loadCaCertPool(config.caCertFilepath)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred loading the API container CA cert")
+			}
+			tlsConfig.RootCAs = caCertPool
+		}
+		if isClientCertProvided {
+			cert, err := loadCertAndKey(config.clientCertFilepath, config.clientKeyFilepath)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred loading the client TLS cert and key for the API container connection")
+			}
+			tlsConfig.Certificates = []tls.Certificate{*cert}
+		}
+		result = append(result, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+
+	authToken, err := readAuthToken(config.authTokenFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the API container auth token")
+	}
+	if authToken != "" {
+		result = append(
+			result,
+			grpc.WithUnaryInterceptor(newUnaryClientAuthInterceptor(authToken)),
+			grpc.WithStreamInterceptor(newStreamClientAuthInterceptor(authToken)),
+		)
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package rpc_security
+
+import (
+	"context"
+	"crypto/subtle"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+const (
+	authorizationMetadataKey = "authorization"
+	bearerTokenPrefix = "Bearer "
+)
+
+// Calls to these services don't need the auth token, so that health probes and tools like grpcurl work without it
+var unauthenticatedServiceFullMethodPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.v1alpha.ServerReflection/",
+	"/grpc.reflection.v1.ServerReflection/",
+}
+
+func newUnaryServerAuthInterceptor(authToken string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isUnauthenticatedMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		if err := verifyAuthToken(ctx, authToken); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func newStreamServerAuthInterceptor(authToken string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isUnauthenticatedMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+		if err := verifyAuthToken(stream.Context(), authToken); err != nil {
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func newUnaryClientAuthInterceptor(authToken string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(addAuthToken(ctx, authToken), method, req, reply, cc, opts...)
+	}
+}
+
+func newStreamClientAuthInterceptor(authToken string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(addAuthToken(ctx, authToken), desc, cc, method, opts...)
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// NOTE: This returns a gRPC status error (rather than a stacktrace error) so that the client gets an Unauthenticated code
+func verifyAuthToken(ctx context.Context, authToken string) error {
+	md, found := metadata.FromIncomingContext(ctx)
+	if !found {
+		return status.Error(codes.Unauthenticated, "No metadata was provided with the request, so it has no auth token")
+	}
+	expectedValue := []byte(bearerTokenPrefix + authToken)
+	for _, value := range md.Get(authorizationMetadataKey) {
+		// Constant-time so that response timing doesn't leak how much of the token was correct
+		if subtle.ConstantTimeCompare([]byte(value), expectedValue) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "The request didn't carry a valid auth token")
+}
+
+func isUnauthenticatedMethod(fullMethod string) bool {
+	for _, prefix := range unauthenticatedServiceFullMethodPrefixes {
+		if strings.HasPrefix(fullMethod, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func addAuthToken(ctx context.Context, authToken string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, authorizationMetadataKey, bearerTokenPrefix + authToken)
+}
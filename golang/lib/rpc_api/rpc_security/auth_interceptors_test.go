@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package rpc_security
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"reflect"
+	"testing"
+)
+
+const (
+	testAuthToken = "correct-token"
+	testAuthenticatedFullMethod = "/api_container_api.TestSuiteService/RunTest"
+)
+
+// Only the context is needed by the stream interceptor
+type contextOnlyServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (stream contextOnlyServerStream) Context() context.Context {
+	return stream.ctx
+}
+
+func TestServerAuthInterceptors(t *testing.T) {
+	testCases := []struct {
+		name string
+		ctx context.Context
+		fullMethod string
+		expectAllowed bool
+	}{
+		{
+			name: "no metadata",
+			ctx: context.Background(),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "no authorization header",
+			ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("other", "value")),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "wrong token",
+			ctx: newIncomingAuthContext("Bearer wrong-token"),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "correct token prefix only",
+			ctx: newIncomingAuthContext("Bearer correct"),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "token without bearer prefix",
+			ctx: newIncomingAuthContext(testAuthToken),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "wrongly-cased bearer prefix",
+			ctx: newIncomingAuthContext("bearer " + testAuthToken),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "empty bearer token",
+			ctx: newIncomingAuthContext("Bearer "),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: false,
+		},
+		{
+			name: "correct token",
+			ctx: newIncomingAuthContext("Bearer " + testAuthToken),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: true,
+		},
+		{
+			name: "correct token among several",
+			ctx: newIncomingAuthContext("Bearer wrong-token", "Bearer " + testAuthToken),
+			fullMethod: testAuthenticatedFullMethod,
+			expectAllowed: true,
+		},
+		{
+			name: "health check without token",
+			ctx: context.Background(),
+			fullMethod: "/grpc.health.v1.Health/Check",
+			expectAllowed: true,
+		},
+		{
+			name: "health watch with wrong token",
+			ctx: newIncomingAuthContext("Bearer wrong-token"),
+			fullMethod: "/grpc.health.v1.Health/Watch",
+			expectAllowed: true,
+		},
+		{
+			name: "v1alpha reflection without token",
+			ctx: context.Background(),
+			fullMethod: "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
+			expectAllowed: true,
+		},
+		{
+			name: "v1 reflection without token",
+			ctx: context.Background(),
+			fullMethod: "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo",
+			expectAllowed: true,
+		},
+		{
+			name: "lookalike of an exempt service without token",
+			ctx: context.Background(),
+			fullMethod: "/grpc.health.v1.HealthExtra/Check",
+			expectAllowed: false,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name + " (unary)", func(t *testing.T) {
+			wasHandlerCalled := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				wasHandlerCalled = true
+				return nil, nil
+			}
+			interceptor := newUnaryServerAuthInterceptor(testAuthToken)
+			_, err := interceptor(testCase.ctx, nil, &grpc.UnaryServerInfo{FullMethod: testCase.fullMethod}, handler)
+			assertAuthResult(t, testCase.expectAllowed, wasHandlerCalled, err)
+		})
+		t.Run(testCase.name + " (stream)", func(t *testing.T) {
+			wasHandlerCalled := false
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				wasHandlerCalled = true
+				return nil
+			}
+			interceptor := newStreamServerAuthInterceptor(testAuthToken)
+			stream := contextOnlyServerStream{ctx: testCase.ctx}
+			err := interceptor(nil, stream, &grpc.StreamServerInfo{FullMethod: testCase.fullMethod}, handler)
+			assertAuthResult(t, testCase.expectAllowed, wasHandlerCalled, err)
+		})
+	}
+}
+
+func TestClientAuthInterceptors(t *testing.T) {
+	expectedAuthValues := []string{"Bearer " + testAuthToken}
+
+	t.Run("unary", func(t *testing.T) {
+		var sentMd metadata.MD
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			sentMd, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+		interceptor := newUnaryClientAuthInterceptor(testAuthToken)
+		if err := interceptor(context.Background(), testAuthenticatedFullMethod, nil, nil, nil, invoker); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		assertClientAuthMetadata(t, sentMd, expectedAuthValues)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		var sentMd metadata.MD
+		streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			sentMd, _ = metadata.FromOutgoingContext(ctx)
+			return nil, nil
+		}
+		interceptor := newStreamClientAuthInterceptor(testAuthToken)
+		if _, err := interceptor(context.Background(), &grpc.StreamDesc{}, nil, testAuthenticatedFullMethod, streamer); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		assertClientAuthMetadata(t, sentMd, expectedAuthValues)
+	})
+
+	t.Run("existing metadata is kept", func(t *testing.T) {
+		var sentMd metadata.MD
+		invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			sentMd, _ = metadata.FromOutgoingContext(ctx)
+			return nil
+		}
+		ctx := metadata.AppendToOutgoingContext(context.Background(), "other", "value")
+		interceptor := newUnaryClientAuthInterceptor(testAuthToken)
+		if err := interceptor(ctx, testAuthenticatedFullMethod, nil, nil, nil, invoker); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		assertClientAuthMetadata(t, sentMd, expectedAuthValues)
+		if !reflect.DeepEqual(sentMd.Get("other"), []string{"value"}) {
+			t.Fatalf("Expected the existing metadata to be kept, but got: %v", sentMd)
+		}
+	})
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func newIncomingAuthContext(authorizationValues ...string) context.Context {
+	md := metadata.MD{}
+	md.Append(authorizationMetadataKey, authorizationValues...)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func assertAuthResult(t *testing.T, expectAllowed bool, wasHandlerCalled bool, err error) {
+	if expectAllowed {
+		if err != nil {
+			t.Fatalf("Expected the call to be allowed, but got error: %v", err)
+		}
+		if !wasHandlerCalled {
+			t.Fatalf("Expected the call to be allowed, but the handler wasn't called")
+		}
+		return
+	}
+	if wasHandlerCalled {
+		t.Fatalf("Expected the call to be rejected, but the handler was called")
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected an error with code '%v', but got: %v", codes.Unauthenticated, err)
+	}
+}
+
+func assertClientAuthMetadata(t *testing.T, sentMd metadata.MD, expectedAuthValues []string) {
+	if actualAuthValues := sentMd.Get(authorizationMetadataKey); !reflect.DeepEqual(actualAuthValues, expectedAuthValues) {
+		t.Fatalf("Expected the call to carry authorization values %v, but got %v", expectedAuthValues, actualAuthValues)
+	}
+	// The metadata the client sends must be accepted by the server-side check
+	if err := verifyAuthToken(metadata.NewIncomingContext(context.Background(), sentMd), testAuthToken); err != nil {
+		t.Fatalf("Expected the sent metadata to pass the server's auth check, but got: %v", err)
+	}
+}
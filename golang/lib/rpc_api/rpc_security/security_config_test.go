@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package rpc_security
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path"
+	"testing"
+	"time"
+)
+
+const (
+	testFilesDirPrefix = "rpc-security-test"
+	certValidity = time.Hour
+	testCallTimeout = 10 * time.Second
+)
+
+// Filepaths of the files needed to exercise the security configs; the single self-signed cert serves as the server
+//  cert, the client cert, and the CA for both
+type testSecurityFiles struct {
+	certFilepath string
+	keyFilepath string
+	notPemFilepath string
+	authTokenFilepath string
+	emptyAuthTokenFilepath string
+	nonexistentFilepath string
+}
+
+func TestServerSecurityConfigGetServerOptions(t *testing.T) {
+	files, dirpath := createTestSecurityFiles(t)
+	defer os.RemoveAll(dirpath)
+
+	testCases := []struct {
+		name string
+		config *ServerSecurityConfig
+		// Only checked if no error is expected
+		expectedNumOptions int
+		expectErr bool
+	}{
+		{
+			name: "empty config is insecure",
+			config: NewServerSecurityConfig("", "", "", ""),
+			expectedNumOptions: 0,
+		},
+		{
+			name: "TLS",
+			config: NewServerSecurityConfig(files.certFilepath, files.keyFilepath, "", ""),
+			expectedNumOptions: 1,
+		},
+		{
+			name: "mutual TLS",
+			config: NewServerSecurityConfig(files.certFilepath, files.keyFilepath, files.certFilepath, ""),
+			expectedNumOptions: 1,
+		},
+		{
+			name: "auth token without TLS",
+			config: NewServerSecurityConfig("", "", "", files.authTokenFilepath),
+			expectedNumOptions: 2,
+		},
+		{
+			name: "TLS and auth token",
+			config: NewServerSecurityConfig(files.certFilepath, files.keyFilepath, "", files.authTokenFilepath),
+			expectedNumOptions: 3,
+		},
+		{
+			name: "client CA without server cert and key",
+			config: NewServerSecurityConfig("", "", files.certFilepath, ""),
+			expectErr: true,
+		},
+		{
+			name: "cert without key",
+			config: NewServerSecurityConfig(files.certFilepath, "", "", ""),
+			expectErr: true,
+		},
+		{
+			name: "key without cert",
+			config: NewServerSecurityConfig("", files.keyFilepath, "", ""),
+			expectErr: true,
+		},
+		{
+			name: "cert and key swapped",
+			config: NewServerSecurityConfig(files.keyFilepath, files.certFilepath, "", ""),
+			expectErr: true,
+		},
+		{
+			name: "client CA that isn't PEM",
+			config: NewServerSecurityConfig(files.certFilepath, files.keyFilepath, files.notPemFilepath, ""),
+			expectErr: true,
+		},
+		{
+			name: "nonexistent client CA",
+			config: NewServerSecurityConfig(files.certFilepath, files.keyFilepath, files.nonexistentFilepath, ""),
+			expectErr: true,
+		},
+		{
+			name: "empty auth token",
+			config: NewServerSecurityConfig("", "", "", files.emptyAuthTokenFilepath),
+			expectErr: true,
+		},
+		{
+			name: "nonexistent auth token",
+			config: NewServerSecurityConfig("", "", "", files.nonexistentFilepath),
+			expectErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options, err := testCase.config.GetServerOptions()
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, but got %v options", len(options))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if len(options) != testCase.expectedNumOptions {
+				t.Fatalf("Expected %v server options, but got %v", testCase.expectedNumOptions, len(options))
+			}
+		})
+	}
+}
+
+func TestApiContainerSecurityConfigGetDialOptions(t *testing.T) {
+	files, dirpath := createTestSecurityFiles(t)
+	defer os.RemoveAll(dirpath)
+
+	testCases := []struct {
+		name string
+		config *ApiContainerSecurityConfig
+		// Only checked if no error is expected
+		expectedNumOptions int
+		expectErr bool
+	}{
+		{
+			name: "empty config is insecure",
+			config: NewApiContainerSecurityConfig("", "", "", ""),
+			expectedNumOptions: 1,
+		},
+		{
+			name: "TLS with CA",
+			config: NewApiContainerSecurityConfig(files.certFilepath, "", "", ""),
+			expectedNumOptions: 1,
+		},
+		{
+			name: "mutual TLS with system roots",
+			config: NewApiContainerSecurityConfig("", files.certFilepath, files.keyFilepath, ""),
+			expectedNumOptions: 1,
+		},
+		{
+			name: "mutual TLS with CA and auth token",
+			config: NewApiContainerSecurityConfig(files.certFilepath, files.certFilepath, files.keyFilepath, files.authTokenFilepath),
+			expectedNumOptions: 3,
+		},
+		{
+			name: "client cert without key",
+			config: NewApiContainerSecurityConfig(files.certFilepath, files.certFilepath, "", ""),
+			expectErr: true,
+		},
+		{
+			name: "client key without cert",
+			config: NewApiContainerSecurityConfig("", "", files.keyFilepath, ""),
+			expectErr: true,
+		},
+		{
+			name: "CA that isn't PEM",
+			config: NewApiContainerSecurityConfig(files.notPemFilepath, "", "", ""),
+			expectErr: true,
+		},
+		{
+			name: "empty auth token",
+			config: NewApiContainerSecurityConfig("", "", "", files.emptyAuthTokenFilepath),
+			expectErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options, err := testCase.config.GetDialOptions()
+			if testCase.expectErr {
+				if err == nil {
+					t.Fatalf("Expected an error, but got %v options", len(options))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, but got: %v", err)
+			}
+			if len(options) != testCase.expectedNumOptions {
+				t.Fatalf("Expected %v dial options, but got %v", testCase.expectedNumOptions, len(options))
+			}
+		})
+	}
+}
+
+// Checks that the server and client options actually work together over a real connection
+func TestSecurityConfigsOverConnection(t *testing.T) {
+	files, dirpath := createTestSecurityFiles(t)
+	defer os.RemoveAll(dirpath)
+
+	serverConfig := NewServerSecurityConfig(files.certFilepath, files.keyFilepath, files.certFilepath, files.authTokenFilepath)
+	serverOptions, err := serverConfig.GetServerOptions()
+	if err != nil {
+		t.Fatalf("An error occurred getting the server options: %v", err)
+	}
+	server := grpc.NewServer(serverOptions...)
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("An error occurred listening: %v", err)
+	}
+	go server.Serve(listener)
+	defer server.Stop()
+
+	testCases := []struct {
+		name string
+		config *ApiContainerSecurityConfig
+		expectedCode codes.Code
+	}{
+		{
+			name: "mutual TLS and auth token",
+			config: NewApiContainerSecurityConfig(files.certFilepath, files.certFilepath, files.keyFilepath, files.authTokenFilepath),
+			expectedCode: codes.OK,
+		},
+		{
+			name: "mutual TLS without auth token is allowed to check health",
+			config: NewApiContainerSecurityConfig(files.certFilepath, files.certFilepath, files.keyFilepath, ""),
+			expectedCode: codes.OK,
+		},
+		{
+			name: "TLS without client cert",
+			config: NewApiContainerSecurityConfig(files.certFilepath, "", "", files.authTokenFilepath),
+			expectedCode: codes.Unavailable,
+		},
+		{
+			name: "plaintext",
+			config: NewApiContainerSecurityConfig("", "", "", files.authTokenFilepath),
+			expectedCode: codes.Unavailable,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			dialOptions, err := testCase.config.GetDialOptions()
+			if err != nil {
+				t.Fatalf("An error occurred getting the dial options: %v", err)
+			}
+			// The cert is issued for localhost, so we dial by name rather than by the listener's IP
+			_, port, err := net.SplitHostPort(listener.Addr().String())
+			if err != nil {
+				t.Fatalf("An error occurred splitting the listener address: %v", err)
+			}
+			conn, err := grpc.Dial(net.JoinHostPort("localhost", port), dialOptions...)
+			if err != nil {
+				t.Fatalf("An error occurred dialing the server: %v", err)
+			}
+			defer conn.Close()
+
+			ctx, cancelFunc := context.WithTimeout(context.Background(), testCallTimeout)
+			defer cancelFunc()
+			_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			if actualCode := status.Code(err); actualCode != testCase.expectedCode {
+				t.Fatalf("Expected code '%v', but got: %v", testCase.expectedCode, err)
+			}
+		})
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Returns the files, and the directory containing them which the caller should remove
+func createTestSecurityFiles(t *testing.T) (*testSecurityFiles, string) {
+	dirpath, err := ioutil.TempDir("", testFilesDirPrefix)
+	if err != nil {
+		t.Fatalf("An error occurred creating a temp directory: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("An error occurred generating a key: %v", err)
+	}
+	certTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: pkix.Name{CommonName: "localhost"},
+		DNSNames: []string{"localhost"},
+		NotBefore: time.Now().Add(-certValidity),
+		NotAfter: time.Now().Add(certValidity),
+		IsCA: true,
+		BasicConstraintsValid: true,
+		KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, certTemplate, certTemplate, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("An error occurred creating a self-signed cert: %v", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("An error occurred serializing the key: %v", err)
+	}
+
+	files := &testSecurityFiles{
+		certFilepath: path.Join(dirpath, "cert.pem"),
+		keyFilepath: path.Join(dirpath, "key.pem"),
+		notPemFilepath: path.Join(dirpath, "not-pem.txt"),
+		authTokenFilepath: path.Join(dirpath, "auth-token"),
+		emptyAuthTokenFilepath: path.Join(dirpath, "empty-auth-token"),
+		nonexistentFilepath: path.Join(dirpath, "nonexistent"),
+	}
+	fileContents := map[string][]byte{
+		files.certFilepath: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer}),
+		files.keyFilepath: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+		files.notPemFilepath: []byte("this isn't a cert"),
+		// The trailing newline must be trimmed, as it would be from a file written with 'echo'
+		files.authTokenFilepath: []byte(testAuthToken + "\n"),
+		files.emptyAuthTokenFilepath: []byte(" \n"),
+	}
+	for filepath, contents := range fileContents {
+		if err := ioutil.WriteFile(filepath, contents, 0600); err != nil {
+			t.Fatalf("An error occurred writing file '%v': %v", filepath, err)
+		}
+	}
+	return files, dirpath
+}
@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package rpc_security
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"strings"
+)
+
+func loadCertAndKey(certFilepath string, keyFilepath string) (*tls.Certificate, error) {
+	if certFilepath == "" || keyFilepath == "" {
+		return nil, stacktrace.NewError("A TLS cert and key must be provided together, but only one of them was provided")
+	}
+	cert, err := tls.LoadX509KeyPair(certFilepath, keyFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(
+			err,
+			"An error occurred loading the TLS cert and key from '%v' and '%v'",
+			certFilepath,
+			keyFilepath,
+		)
+	}
+	return &cert, nil
+}
+
+func loadCaCertPool(caCertFilepath string) (*x509.CertPool, error) {
+	caCertBytes, err := ioutil.ReadFile(caCertFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the CA cert at '%v'", caCertFilepath)
+	}
+	result := x509.NewCertPool()
+	if !result.AppendCertsFromPEM(caCertBytes) {
+		return nil, stacktrace.NewError("No PEM-encoded certs could be parsed from CA cert file '%v'", caCertFilepath)
+	}
+	return result, nil
+}
+
+// Returns empty string if no auth token filepath is given
+func readAuthToken(authTokenFilepath string) (string, error) {
+	if authTokenFilepath == "" {
+		return "", nil
+	}
+	authTokenBytes, err := ioutil.ReadFile(authTokenFilepath)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred reading the auth token file at '%v'", authTokenFilepath)
+	}
+	authToken := strings.TrimSpace(string(authTokenBytes))
+	if authToken == "" {
+		return "", stacktrace.NewError("Auth token file '%v' is empty", authTokenFilepath)
+	}
+	return authToken, nil
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package rpc_security
+
+import (
+	"crypto/tls"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+/*
+Configures how the testsuite secures its own gRPC server
+
+Every setting is optional, and an empty config results in the same insecure server as before:
+ - If a cert and key are provided, the server uses TLS with them
+ - If a client CA cert is also provided, TLS becomes mutual: the server will only accept clients with a cert signed by
+	the CA
+ - If an auth token is provided, the server will reject calls that don't carry it as a bearer token, except for calls
+	to the health and reflection services
+
+NOTE: None of these are used for the testsuite's connection to the Kurtosis API container, which is configured
+	separately by ApiContainerSecurityConfig, so that the credentials callers present to us are never sent onwards
+*/
+type ServerSecurityConfig struct {
+	certFilepath string
+	keyFilepath string
+	clientCaCertFilepath string
+	authTokenFilepath string
+}
+
+func NewServerSecurityConfig(certFilepath string, keyFilepath string, clientCaCertFilepath string, authTokenFilepath string) *ServerSecurityConfig {
+	return &ServerSecurityConfig{certFilepath: certFilepath, keyFilepath: keyFilepath, clientCaCertFilepath: clientCaCertFilepath, authTokenFilepath: authTokenFilepath}
+}
+
+func (config ServerSecurityConfig) GetServerOptions() ([]grpc.ServerOption, error) {
+	result := []grpc.ServerOption{}
+
+	if config.certFilepath == "" && config.keyFilepath == "" {
+		if config.clientCaCertFilepath != "" {
+			return nil, stacktrace.NewError("A client CA cert was provided without a server cert and key, but the client CA cert is only used for mutual TLS")
+		}
+	} else {
+		cert, err := loadCertAndKey(config.certFilepath, config.keyFilepath)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred loading the server's TLS cert and key")
+		}
+		tlsConfig := &tls.Config{
+			Certificates: []tls.Certificate{*cert},
+		}
+		if config.clientCaCertFilepath != "" {
+			clientCaCertPool, err := loadCaCertPool(config.clientCaCertFilepath)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred loading the client CA cert")
+			}
+			tlsConfig.ClientCAs = clientCaCertPool
+			tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+		}
+		result = append(result, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	authToken, err := readAuthToken(config.authTokenFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the server's auth token")
+	}
+	if authToken != "" {
+		result = append(
+			result,
+			grpc.UnaryInterceptor(newUnaryServerAuthInterceptor(authToken)),
+			grpc.StreamInterceptor(newStreamServerAuthInterceptor(authToken)),
+		)
+	}
+	return result, nil
+}
@@ -0,0 +1,174 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+/*
+Helpers for copying files into and out of running service containers
+
+Kurtosis doesn't have a dedicated API for this, so these helpers stage the data in a file generated on the suite
+	execution volume (which is mounted on both the testsuite container and the service container) and then move it
+	into place with a command executed inside the service container. This means the service's image must contain
+	'mkdir', 'cp' and 'tar' binaries.
+ */
+package service_files
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/palantir/stacktrace"
+	"io"
+	"os"
+	"path"
+	"sync/atomic"
+)
+
+const (
+	stagingFileKeyPrefix = "service-files-staging-"
+
+	successExitCode int32 = 0
+)
+
+// Used to give every staging file a distinct key, since keys must be unique per service
+var nextStagingFileNum uint64 = 0
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func UploadFile(serviceCtx *services.ServiceContext, contents io.Reader, destFilepath string) error {
+	stagingFilepaths, err := createStagingFile(serviceCtx)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the staging file for the upload")
+	}
+	defer removeStagingFile(serviceCtx, stagingFilepaths)
+
+	if err := writeStagingFile(stagingFilepaths, contents); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the contents to upload to the staging file")
+	}
+
+	if err := execCommand(serviceCtx, []string{"mkdir", "-p", path.Dir(destFilepath)}); err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the parent directory of '%v'", destFilepath)
+	}
+	if err := execCommand(serviceCtx, []string{"cp", stagingFilepaths.AbsoluteFilepathOnServiceContainer, destFilepath}); err != nil {
+		return stacktrace.Propagate(err, "An error occurred copying the uploaded file to '%v'", destFilepath)
+	}
+	return nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func UploadTar(serviceCtx *services.ServiceContext, tarStream io.Reader, destDirpath string) error {
+	stagingFilepaths, err := createStagingFile(serviceCtx)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the staging file for the upload")
+	}
+	defer removeStagingFile(serviceCtx, stagingFilepaths)
+
+	if err := writeStagingFile(stagingFilepaths, tarStream); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the TAR to upload to the staging file")
+	}
+
+	if err := execCommand(serviceCtx, []string{"mkdir", "-p", destDirpath}); err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating destination directory '%v'", destDirpath)
+	}
+	extractCmd := []string{"tar", "-xf", stagingFilepaths.AbsoluteFilepathOnServiceContainer, "-C", destDirpath}
+	if err := execCommand(serviceCtx, extractCmd); err != nil {
+		return stacktrace.Propagate(err, "An error occurred extracting the uploaded TAR into '%v'", destDirpath)
+	}
+	return nil
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func DownloadAsTar(serviceCtx *services.ServiceContext, srcPath string, output io.Writer) error {
+	stagingFilepaths, err := createStagingFile(serviceCtx)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the staging file for the download")
+	}
+	defer removeStagingFile(serviceCtx, stagingFilepaths)
+
+	// We TAR from the parent directory so that the archive contains the source's name as its top-level entry
+	archiveCmd := []string{
+		"tar",
+		"-cf",
+		stagingFilepaths.AbsoluteFilepathOnServiceContainer,
+		"-C",
+		path.Dir(srcPath),
+		path.Base(srcPath),
+	}
+	if err := execCommand(serviceCtx, archiveCmd); err != nil {
+		return stacktrace.Propagate(err, "An error occurred archiving '%v' inside the service container", srcPath)
+	}
+
+	fp, err := os.Open(stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer)
+	if err != nil {
+		return stacktrace.Propagate(
+			err,
+			"An error occurred opening staging file '%v' to read the downloaded TAR",
+			stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer,
+		)
+	}
+	defer fp.Close()
+	if _, err := io.Copy(output, fp); err != nil {
+		return stacktrace.Propagate(err, "An error occurred copying the downloaded TAR to the output")
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func createStagingFile(serviceCtx *services.ServiceContext) (*services.GeneratedFileFilepaths, error) {
+	stagingFileKey := fmt.Sprintf("%v%v", stagingFileKeyPrefix, atomic.AddUint64(&nextStagingFileNum, 1))
+	generatedFiles, err := serviceCtx.GenerateFiles(map[string]bool{
+		stagingFileKey: true,
+	})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred generating staging file '%v'", stagingFileKey)
+	}
+	stagingFilepaths, found := generatedFiles[stagingFileKey]
+	if !found {
+		return nil, stacktrace.NewError("Staging file '%v' was requested, but wasn't in the generated files", stagingFileKey)
+	}
+	return stagingFilepaths, nil
+}
+
+func writeStagingFile(stagingFilepaths *services.GeneratedFileFilepaths, contents io.Reader) error {
+	fp, err := os.Create(stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening staging file '%v'", stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer)
+	}
+	defer fp.Close()
+	if _, err := io.Copy(fp, contents); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing to staging file '%v'", stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer)
+	}
+	return nil
+}
+
+// Staging files are only needed for the duration of a single copy, so we don't want them piling up on the volume
+func removeStagingFile(serviceCtx *services.ServiceContext, stagingFilepaths *services.GeneratedFileFilepaths) {
+	if err := os.Remove(stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer); err != nil {
+		logging.WithServiceID(serviceCtx.GetServiceID()).Warnf(
+			"An error occurred removing staging file '%v'; it will remain on the suite execution volume: %v",
+			stagingFilepaths.AbsoluteFilepathOnTestsuiteContainer,
+			err,
+		)
+	}
+}
+
+func execCommand(serviceCtx *services.ServiceContext, command []string) error {
+	exitCode, logOutput, err := serviceCtx.ExecCommand(command)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred executing command '%v'", command)
+	}
+	if exitCode != successExitCode {
+		logOutputStr := ""
+		if logOutput != nil {
+			logOutputStr = string(*logOutput)
+		}
+		return stacktrace.NewError(
+			"Command '%v' returned non-zero exit code %v with log output:\n%v",
+			command,
+			exitCode,
+			logOutputStr,
+		)
+	}
+	return nil
+}
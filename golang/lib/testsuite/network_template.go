@@ -0,0 +1,19 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package testsuite
+
+import "github.com/kurtosis-tech/kurtosis-client/golang/networks"
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type NetworkTemplate func(networkCtx *networks.NetworkContext) error
+
+// Optional interface that a TestSuite can implement to declare named network templates, which tests can reference
+//  using TestConfigurationBuilder.WithNetworkTemplate
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type NetworkTemplateProvider interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	GetNetworkTemplates() map[string]NetworkTemplate
+}
@@ -5,7 +5,10 @@
 
 package testsuite
 
-import "github.com/kurtosis-tech/kurtosis-client/golang/networks"
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+)
 
 // Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 type Test interface {
@@ -15,8 +18,24 @@ type Test interface {
 	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 	Setup(networkCtx *networks.NetworkContext) (networks.Network, error)
 
-	// NOTE: if Go had generics, 'network' would be a parameterized type representing the network that this test consumes
-	// as produced by the NetworkLoader
+	// NOTE: 'network' isn't a parameterized type here so that tests with different network types can live in the same
+	// map; implement TypedTest and use AdaptTypedTest to get a typed network instead
 	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 	Run(network networks.Network) error
 }
+
+// Optional interface that a Test can implement to be told, via context cancellation, when the testsuite is shutting
+//  down mid-test; if implemented, RunWithContext will be called instead of Run
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type CancellableTest interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	RunWithContext(ctx context.Context, network networks.Network) error
+}
+
+// Optional interface that a Test can implement to clean up after itself once it has run, even if it failed or was
+//  interrupted
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type TeardownHook interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Teardown(network networks.Network) error
+}
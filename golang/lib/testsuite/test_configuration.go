@@ -21,4 +21,12 @@ type TestConfiguration struct {
 	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 	FilesArtifactUrls map[services.FilesArtifactID]string
 
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	NetworkTemplateName string
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Dependencies map[string]bool
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Priority int32
 }
@@ -7,6 +7,8 @@ const (
 	defaultSetupTimeoutSeconds = 180;
 	defaultRunTimeoutSeconds = 180;
 	defaultPartitioningEnabled = false;
+	noNetworkTemplateName = "";
+	defaultPriority = 0;
 	// ^^^^^^^^^ Update the docs if you change these ^^^^^^^^^^^
 )
 
@@ -16,6 +18,9 @@ type TestConfigurationBuilder struct {
 	runTimeoutSeconds uint32
 	isPartioningEnabled bool
 	filesArtifactUrls map[services.FilesArtifactID]string
+	networkTemplateName string
+	dependencies map[string]bool
+	priority int32
 }
 
 func NewTestConfigurationBuilder() *TestConfigurationBuilder {
@@ -24,6 +29,9 @@ func NewTestConfigurationBuilder() *TestConfigurationBuilder {
 		runTimeoutSeconds:   defaultRunTimeoutSeconds,
 		isPartioningEnabled: defaultPartitioningEnabled,
 		filesArtifactUrls:   map[services.FilesArtifactID]string{},
+		networkTemplateName: noNetworkTemplateName,
+		dependencies:        map[string]bool{},
+		priority:            defaultPriority,
 	}
 }
 
@@ -47,11 +55,32 @@ func (builder *TestConfigurationBuilder) WithFilesArtifactUrls(filesArtifactUrls
 	return builder
 }
 
+func (builder *TestConfigurationBuilder) WithNetworkTemplate(networkTemplateName string) *TestConfigurationBuilder {
+	builder.networkTemplateName = networkTemplateName
+	return builder
+}
+
+// NOTE: The dependencies are the names of other tests, as returned by the keys of TestSuite.GetTests
+func (builder *TestConfigurationBuilder) WithDependencies(testNames ...string) *TestConfigurationBuilder {
+	for _, testName := range testNames {
+		builder.dependencies[testName] = true
+	}
+	return builder
+}
+
+func (builder *TestConfigurationBuilder) WithPriority(priority int32) *TestConfigurationBuilder {
+	builder.priority = priority
+	return builder
+}
+
 func (builder TestConfigurationBuilder) Build() *TestConfiguration {
 	return &TestConfiguration{
 		SetupTimeoutSeconds:   builder.setupTimeoutSeconds,
 		RunTimeoutSeconds:     builder.runTimeoutSeconds,
 		IsPartitioningEnabled: builder.isPartioningEnabled,
 		FilesArtifactUrls:     builder.filesArtifactUrls,
+		NetworkTemplateName:   builder.networkTemplateName,
+		Dependencies:          builder.dependencies,
+		Priority:              builder.priority,
 	}
 }
@@ -13,3 +13,24 @@ type TestSuite interface {
 	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
 	GetNetworkWidthBits() uint32
 }
+
+// Optional interface that a TestSuite can implement to prepare shared fixtures once per testsuite container
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type BeforeAllHook interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	BeforeAll() error
+}
+
+// Optional interface that a TestSuite can implement to clean up shared fixtures once per testsuite container
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type AfterAllHook interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	AfterAll() error
+}
+
+// Optional interface that a TestSuite can implement to tell Kurtosis to stop starting new tests once any test fails
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type FailFastPolicyProvider interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	IsFailFast() bool
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package testsuite
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
+	"github.com/palantir/stacktrace"
+)
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+type TypedTest[N networks.Network] interface {
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Configure(builder *TestConfigurationBuilder)
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Setup(networkCtx *networks.NetworkContext) (N, error)
+
+	// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+	Run(network N) error
+}
+
+// Adapts a TypedTest to the untyped Test interface, so that it can be returned from TestSuite.GetTests
+type typedTestAdapter[N networks.Network] struct {
+	typedTest TypedTest[N]
+}
+
+// Docs available at https://docs.kurtosistech.com/kurtosis-libs/lib-documentation
+func AdaptTypedTest[N networks.Network](typedTest TypedTest[N]) Test {
+	return &typedTestAdapter[N]{typedTest: typedTest}
+}
+
+func (adapter typedTestAdapter[N]) Configure(builder *TestConfigurationBuilder) {
+	adapter.typedTest.Configure(builder)
+}
+
+func (adapter typedTestAdapter[N]) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+	network, err := adapter.typedTest.Setup(networkCtx)
+	if err != nil {
+		// Returned explicitly so that callers don't receive a non-nil interface wrapping a nil N
+		return nil, err
+	}
+	return network, nil
+}
+
+func (adapter typedTestAdapter[N]) Run(network networks.Network) error {
+	castedNetwork, err := casting.CastNetwork[N](network)
+	if err != nil {
+		return stacktrace.Propagate(err, "The network passed to the test wasn't the type that the test's Setup returned")
+	}
+	return adapter.typedTest.Run(castedNetwork)
+}
+
+// The adapter always implements CancellableTest and TeardownHook, and forwards to the typed test if it implements
+//  the typed equivalents
+func (adapter typedTestAdapter[N]) RunWithContext(ctx context.Context, network networks.Network) error {
+	cancellableTest, ok := adapter.typedTest.(interface{ RunWithContext(ctx context.Context, network N) error })
+	if !ok {
+		return adapter.Run(network)
+	}
+	castedNetwork, err := casting.CastNetwork[N](network)
+	if err != nil {
+		return stacktrace.Propagate(err, "The network passed to the test wasn't the type that the test's Setup returned")
+	}
+	return cancellableTest.RunWithContext(ctx, castedNetwork)
+}
+
+func (adapter typedTestAdapter[N]) Teardown(network networks.Network) error {
+	teardownHook, ok := adapter.typedTest.(interface{ Teardown(network N) error })
+	if !ok {
+		return nil
+	}
+	castedNetwork, err := casting.CastNetwork[N](network)
+	if err != nil {
+		return stacktrace.Propagate(err, "The network passed to the test's teardown wasn't the type that the test's Setup returned")
+	}
+	return teardownHook.Teardown(castedNetwork)
+}
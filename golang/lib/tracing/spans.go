@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package tracing
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/grpc_method_names"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"sync"
+)
+
+const (
+	tracerName = "github.com/kurtosis-tech/kurtosis-libs/golang/lib"
+
+	TestNameAttributeKey = attribute.Key("kurtosis.test.name")
+	ServiceIdAttributeKey = attribute.Key("kurtosis.service.id")
+	PartitionIdAttributeKey = attribute.Key("kurtosis.partition.id")
+	RpcMethodAttributeKey = attribute.Key("rpc.method")
+)
+
+// The API container client is called by NetworkContext with contexts that don't descend from the phase's context, so
+//  the spans of the phases currently in progress are tracked here for the API container calls to be parented to
+// A testsuite container only ever runs one test at a time, so a single process-wide stack is enough
+// NOTE: Calls that aren't part of a test (e.g. GetTestSuiteMetadata) can overlap with a test's phases, so phases may end
+//  out of order and each one removes its own entry rather than whatever is on top
+var phaseSpanCtxs = &phaseSpanCtxStack{
+	ctxs: []context.Context{},
+}
+
+// Implemented by the generated protobuf args of the API container calls that concern a single service
+type serviceIdGetter interface {
+	GetServiceId() string
+}
+
+// Implemented by the generated protobuf args of the API container calls that concern a partition
+type partitionIdGetter interface {
+	GetPartitionId() string
+}
+
+// Starts a span for a phase of the testsuite (e.g. a test's setup), which the spans of any Kurtosis API container calls
+//  made before the phase ends will become children of
+func StartPhaseSpan(ctx context.Context, spanName string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(tracerName).Start(ctx, spanName, trace.WithAttributes(attributes...))
+	phaseSpanCtxs.push(spanCtx)
+	return spanCtx, span
+}
+
+// Ends a span started with StartPhaseSpan, recording the error that the phase returned (if any)
+func EndPhaseSpan(span trace.Span, err error) {
+	phaseSpanCtxs.remove(span)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Creates a gRPC client interceptor that records a span for each call to the Kurtosis API container
+func NewApiContainerClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+			ctx context.Context,
+			fullMethod string,
+			req interface{},
+			reply interface{},
+			conn *grpc.ClientConn,
+			invoker grpc.UnaryInvoker,
+			opts ...grpc.CallOption) error {
+		parentCtx := ctx
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			parentCtx = phaseSpanCtxs.peek(ctx)
+		}
+
+		methodName := grpc_method_names.GetMethodName(fullMethod)
+		attributes := []attribute.KeyValue{
+			RpcMethodAttributeKey.String(methodName),
+		}
+		if typedReq, ok := req.(serviceIdGetter); ok {
+			attributes = append(attributes, ServiceIdAttributeKey.String(typedReq.GetServiceId()))
+		}
+		if typedReq, ok := req.(partitionIdGetter); ok {
+			attributes = append(attributes, PartitionIdAttributeKey.String(typedReq.GetPartitionId()))
+		}
+
+		spanCtx, span := otel.Tracer(tracerName).Start(
+			parentCtx,
+			methodName,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attributes...),
+		)
+		defer span.End()
+
+		// The call itself keeps the original context, so that its deadline and cancellation still apply
+		callCtx := trace.ContextWithSpan(ctx, trace.SpanFromContext(spanCtx))
+		if err := invoker(callCtx, fullMethod, req, reply, conn, opts...); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+		return nil
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type phaseSpanCtxStack struct {
+	mutex sync.Mutex
+	ctxs []context.Context
+}
+
+func (stack *phaseSpanCtxStack) push(ctx context.Context) {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+	stack.ctxs = append(stack.ctxs, ctx)
+}
+
+// Removes the context of the given span, wherever it is in the stack
+func (stack *phaseSpanCtxStack) remove(span trace.Span) {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+	for i := len(stack.ctxs) - 1; i >= 0; i-- {
+		if trace.SpanFromContext(stack.ctxs[i]).SpanContext().Equal(span.SpanContext()) {
+			stack.ctxs = append(stack.ctxs[:i], stack.ctxs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Returns the context of the innermost phase in progress, or the default if no phase is in progress
+func (stack *phaseSpanCtxStack) peek(defaultCtx context.Context) context.Context {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+	if len(stack.ctxs) == 0 {
+		return defaultCtx
+	}
+	return stack.ctxs[len(stack.ctxs)-1]
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package tracing
+
+import (
+	"context"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"os"
+)
+
+const (
+	serviceName = "kurtosis-testsuite"
+
+	// The attribute key that OpenTelemetry backends use to group traces by the service that produced them
+	serviceNameAttributeKey = "service.name"
+
+	traceOutputFilePerms = 0644
+)
+
+/*
+Configures where the testsuite exports traces of its test phases and Kurtosis API container calls
+
+Every setting is optional, and an empty config disables tracing:
+ - If an OTLP endpoint is provided, spans are exported over gRPC to the OpenTelemetry collector at that 'address:port'
+ - If a trace output filepath is provided (e.g. on the suite execution volume), spans are written to the file as JSON,
+	which is useful when no collector is available
+*/
+type TracingConfig struct {
+	otlpEndpoint string
+	traceOutputFilepath string
+}
+
+func NewTracingConfig(otlpEndpoint string, traceOutputFilepath string) *TracingConfig {
+	return &TracingConfig{otlpEndpoint: otlpEndpoint, traceOutputFilepath: traceOutputFilepath}
+}
+
+// Sets up the global tracer provider, returning a function that flushes the remaining spans and releases resources,
+//  giving up on flushing when the given context is done
+func (config TracingConfig) InitTracing() (func(ctx context.Context), error) {
+	exporters := []sdktrace.SpanExporter{}
+	if config.otlpEndpoint != "" {
+		otlpExporter, err := otlptracegrpc.New(
+			context.Background(),
+			otlptracegrpc.WithEndpoint(config.otlpEndpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred creating the OTLP exporter for endpoint '%v'", config.otlpEndpoint)
+		}
+		exporters = append(exporters, otlpExporter)
+	}
+
+	var traceOutputFp *os.File = nil
+	if config.traceOutputFilepath != "" {
+		fp, err := os.OpenFile(config.traceOutputFilepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, traceOutputFilePerms)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred opening trace output file '%v'", config.traceOutputFilepath)
+		}
+		traceOutputFp = fp
+		fileExporter, err := stdouttrace.New(stdouttrace.WithWriter(fp))
+		if err != nil {
+			fp.Close()
+			return nil, stacktrace.Propagate(err, "An error occurred creating the exporter for trace output file '%v'", config.traceOutputFilepath)
+		}
+		exporters = append(exporters, fileExporter)
+	}
+
+	// With no exporters, the global tracer provider stays as OpenTelemetry's default no-op one
+	if len(exporters) == 0 {
+		return func(ctx context.Context) {}, nil
+	}
+
+	tracerProviderOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String(serviceNameAttributeKey, serviceName))),
+	}
+	for _, exporter := range exporters {
+		tracerProviderOpts = append(tracerProviderOpts, sdktrace.WithBatcher(exporter))
+	}
+	tracerProvider := sdktrace.NewTracerProvider(tracerProviderOpts...)
+	otel.SetTracerProvider(tracerProvider)
+
+	shutdownFunc := func(ctx context.Context) {
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			logrus.Warnf("An error occurred flushing the remaining spans; some spans may not have been exported: %v", err)
+		}
+		if traceOutputFp != nil {
+			if err := traceOutputFp.Close(); err != nil {
+				logrus.Warnf("An error occurred closing trace output file '%v': %v", config.traceOutputFilepath, err)
+			}
+		}
+	}
+	return shutdownFunc, nil
+}
@@ -11,12 +11,13 @@ type ExampleTestsuiteArgs struct {
 		NEW USER ONBOARDING:
 		- Change this property name to reflect the name of your custom service image.
 		- Change the string after "json:" to reflect the customServiceImage key in the json in build-and-run.sh.
+		- Change the string after "description:" to describe your custom service image.
 	*/
-	MyCustomServiceImage string		`json:"myCustomServiceImage"`
+	MyCustomServiceImage string		`json:"myCustomServiceImage" required:"true" description:"Docker image of the custom service"`
 
-	ApiServiceImage	string 			`json:"apiServiceImage"`
-	DatastoreServiceImage string	`json:"datastoreServiceImage"`
+	ApiServiceImage	string 			`json:"apiServiceImage" required:"true" description:"Docker image of the example API service"`
+	DatastoreServiceImage string	`json:"datastoreServiceImage" required:"true" description:"Docker image of the example datastore service"`
 
 	// Indicates that this testsuite is being run as part of CI testing in Kurtosis Core
-	IsKurtosisCoreDevMode bool		`json:"isKurtosisCoreDevMode"`
+	IsKurtosisCoreDevMode bool		`json:"isKurtosisCoreDevMode" default:"false" description:"Indicates that the testsuite is being run as part of CI testing in Kurtosis Core"`
 }
@@ -6,12 +6,12 @@
 package execution_impl
 
 import (
-	"encoding/json"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/custom_params"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
-	"strings"
 )
 
 type ExampleTestsuiteConfigurator struct {}
@@ -26,22 +26,17 @@ func (t ExampleTestsuiteConfigurator) SetLogLevel(logLevelStr string) error {
 		return stacktrace.Propagate(err, "An error occurred parsing loglevel string '%v'", logLevelStr)
 	}
 	logrus.SetLevel(level)
-	logrus.SetFormatter(&logrus.TextFormatter{
-		ForceColors:   true,
-		FullTimestamp: true,
-	})
+	if err := logging.SetLogFormat(logrus.StandardLogger(), logging.TextLogFormat); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the log format")
+	}
 	return nil
 }
 
 func (t ExampleTestsuiteConfigurator) ParseParamsAndCreateSuite(paramsJsonStr string) (testsuite.TestSuite, error) {
-	paramsJsonBytes := []byte(paramsJsonStr)
-	var args ExampleTestsuiteArgs
-	if err := json.Unmarshal(paramsJsonBytes, &args); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred deserializing the testsuite params JSON")
-	}
-
-	if err := validateArgs(args); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred validating the deserialized testsuite params")
+	// The struct tags on ExampleTestsuiteArgs take care of validating the params
+	args, err := custom_params.ParseParams[ExampleTestsuiteArgs](paramsJsonStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the testsuite params JSON")
 	}
 
 	/*
@@ -56,19 +51,10 @@ func (t ExampleTestsuiteConfigurator) ParseParamsAndCreateSuite(paramsJsonStr st
 	return suite, nil
 }
 
-func validateArgs(args ExampleTestsuiteArgs) error {
-	if strings.TrimSpace(args.ApiServiceImage) == "" {
-		return stacktrace.NewError("API service image is empty")
-	}
-	if strings.TrimSpace(args.DatastoreServiceImage) == "" {
-		return stacktrace.NewError("Datastore service image is empty")
-	}
-	/*
-		NEW USER ONBOARDING:
-		- Change the "MyCustomServiceImage" argument here to your own actual custom service image.
-	*/
-	if strings.TrimSpace(args.MyCustomServiceImage) == "" {
-		return stacktrace.NewError("Custom service image is empty.")
+func (t ExampleTestsuiteConfigurator) GetParamsJsonSchema() ([]byte, error) {
+	schema, err := custom_params.GetJsonSchema[ExampleTestsuiteArgs]()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred generating the JSON schema of the testsuite params")
 	}
-	return nil
+	return schema, nil
 }
@@ -8,18 +8,40 @@ package main
 import (
 	"flag"
 	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/custom_params"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/execution"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/logging"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/rpc_api_consts"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/rpc_security"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/tracing"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/execution_impl"
+	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
 	"os"
+	"time"
 )
 
 const (
 	successExitCode = 0
 	failureExitCode = 1
+	interruptedExitCode = 2
+
+	// Docker gives a container 10 seconds to stop by default, so these add up to a little less than that
+	defaultShutdownTimeout = 9 * time.Second
+	defaultInterruptedTestGracePeriod = 3 * time.Second
+	defaultTelemetryFlushTimeout = 1 * time.Second
 )
 
 func main() {
+	customParamsFilepathArg := flag.String(
+		"custom-params-filepath",
+		"",
+		fmt.Sprintf(
+			"Filepath of a JSON or YAML file containing custom params, which are overridden by --custom-params-json and then by '%v'-prefixed environment variables",
+			custom_params.EnvVarParamPrefix,
+		),
+	)
+
 	customParamsJsonArg := flag.String(
 		"custom-params-json",
 		"{}",
@@ -38,16 +60,171 @@ func main() {
 		"String indicating the loglevel that the test suite should output with",
 	)
 
+	logFormatArg := flag.String(
+		"log-format",
+		"",
+		fmt.Sprintf(
+			"Format that the testsuite should output logs in, from %v; if empty, the format set by the testsuite configurator is used",
+			[]logging.LogFormat{logging.TextLogFormat, logging.JsonLogFormat, logging.LogfmtLogFormat},
+		),
+	)
+
+	tlsCertFilepathArg := flag.String(
+		"tls-cert-filepath",
+		"",
+		"Filepath of the PEM-encoded cert that the testsuite's gRPC server will serve with; the server's TLS is disabled if empty",
+	)
+
+	tlsKeyFilepathArg := flag.String(
+		"tls-key-filepath",
+		"",
+		"Filepath of the PEM-encoded private key for the cert passed in via --tls-cert-filepath",
+	)
+
+	tlsCaCertFilepathArg := flag.String(
+		"tls-ca-cert-filepath",
+		"",
+		"Filepath of a PEM-encoded CA cert that clients' certs must be signed by, enabling mutual TLS on the testsuite's gRPC server; if empty, clients aren't verified",
+	)
+
+	authTokenFilepathArg := flag.String(
+		"auth-token-filepath",
+		"",
+		"Filepath of a file containing a bearer token that callers of the testsuite's gRPC server must provide; the token is never sent to the Kurtosis API container, and token auth is disabled if empty",
+	)
+
+	apiContainerTlsCaCertFilepathArg := flag.String(
+		"api-container-tls-ca-cert-filepath",
+		"",
+		"Filepath of a PEM-encoded CA cert that the Kurtosis API container's cert must be signed by, enabling TLS on the connection to it; the connection is insecure if this and the client cert are empty",
+	)
+
+	apiContainerTlsCertFilepathArg := flag.String(
+		"api-container-tls-cert-filepath",
+		"",
+		"Filepath of a PEM-encoded client cert that the testsuite will present to the Kurtosis API container, enabling TLS on the connection to it",
+	)
+
+	apiContainerTlsKeyFilepathArg := flag.String(
+		"api-container-tls-key-filepath",
+		"",
+		"Filepath of the PEM-encoded private key for the cert passed in via --api-container-tls-cert-filepath",
+	)
+
+	apiContainerAuthTokenFilepathArg := flag.String(
+		"api-container-auth-token-filepath",
+		"",
+		"Filepath of a file containing a bearer token that the testsuite will send on every call to the Kurtosis API container; no token is sent if empty",
+	)
+
+	otlpEndpointArg := flag.String(
+		"otlp-endpoint",
+		"",
+		"Address in the form address:port of an OpenTelemetry collector that traces of the testsuite will be exported to over OTLP/gRPC; not exported if empty",
+	)
+
+	traceOutputFilepathArg := flag.String(
+		"trace-output-filepath",
+		"",
+		"Filepath (e.g. on the suite execution volume) that traces of the testsuite will be written to as JSON; not written if empty",
+	)
+
+	listenAddressArg := flag.String(
+		"listen-address",
+		fmt.Sprintf(":%v", rpc_api_consts.ListenPort),
+		"Address the testsuite's gRPC server will listen on, either as 'address:port' (where port 0 picks a free port) or as 'unix://' followed by a Unix socket filepath",
+	)
+
+	listenAddressOutputFilepathArg := flag.String(
+		"listen-address-output-filepath",
+		"",
+		"If non-empty, the address the testsuite's gRPC server actually listens on will be written to this filepath (useful with port 0)",
+	)
+
+	printParamsJsonSchemaArg := flag.Bool(
+		"print-params-json-schema",
+		false,
+		"If set, the JSON schema of the custom params that --custom-params-json accepts will be printed and the testsuite will exit",
+	)
+
+	metricsListenAddressArg := flag.String(
+		"metrics-listen-address",
+		"",
+		"Address in the form address:port (where port 0 picks a free port) that the testsuite will serve Prometheus metrics on at '/metrics'; not served if empty",
+	)
+
+	shutdownTimeoutArg := flag.Duration(
+		"shutdown-timeout",
+		defaultShutdownTimeout,
+		"How long the testsuite may take to shut down after receiving a termination signal, including the interrupted test's teardown and flushing the telemetry; should be less than the testsuite container's stop timeout",
+	)
+
+	interruptedTestGracePeriodArg := flag.Duration(
+		"interrupted-test-grace-period",
+		defaultInterruptedTestGracePeriod,
+		"How long a test interrupted by shutdown gets to return before its teardown is run anyway; must leave room for the teardown and --telemetry-flush-timeout within --shutdown-timeout",
+	)
+
+	telemetryFlushTimeoutArg := flag.Duration(
+		"telemetry-flush-timeout",
+		defaultTelemetryFlushTimeout,
+		"How much of --shutdown-timeout is reserved for flushing the remaining trace spans and stopping the metrics server",
+	)
+
 	flag.Parse()
 
 	// >>>>>>>>>>>>>>>>>>> REPLACE WITH YOUR OWN CONFIGURATOR <<<<<<<<<<<<<<<<<<<<<<<<
 	configurator := execution_impl.NewExampleTestsuiteConfigurator()
 	// >>>>>>>>>>>>>>>>>>> REPLACE WITH YOUR OWN CONFIGURATOR <<<<<<<<<<<<<<<<<<<<<<<<
 
-	suiteExecutor := execution.NewTestSuiteExecutor(*kurtosisApiSocketArg, *logLevelArg, *customParamsJsonArg, configurator)
+	if *printParamsJsonSchemaArg {
+		if err := execution.PrintParamsJsonSchema(configurator, os.Stdout); err != nil {
+			logrus.Errorf("An error occurred printing the params JSON schema:")
+			fmt.Fprintln(logrus.StandardLogger().Out, err)
+			os.Exit(failureExitCode)
+		}
+		os.Exit(successExitCode)
+	}
+
+	serverSecurityConfig := rpc_security.NewServerSecurityConfig(
+		*tlsCertFilepathArg,
+		*tlsKeyFilepathArg,
+		*tlsCaCertFilepathArg,
+		*authTokenFilepathArg,
+	)
+
+	apiContainerSecurityConfig := rpc_security.NewApiContainerSecurityConfig(
+		*apiContainerTlsCaCertFilepathArg,
+		*apiContainerTlsCertFilepathArg,
+		*apiContainerTlsKeyFilepathArg,
+		*apiContainerAuthTokenFilepathArg,
+	)
+
+	tracingConfig := tracing.NewTracingConfig(*otlpEndpointArg, *traceOutputFilepathArg)
+
+	shutdownConfig := execution.NewShutdownConfig(*shutdownTimeoutArg, *interruptedTestGracePeriodArg, *telemetryFlushTimeoutArg)
+
+	suiteExecutor := execution.NewTestSuiteExecutor(
+		*kurtosisApiSocketArg,
+		*logLevelArg,
+		*logFormatArg,
+		*customParamsFilepathArg,
+		*customParamsJsonArg,
+		configurator,
+		serverSecurityConfig,
+		apiContainerSecurityConfig,
+		tracingConfig,
+		*listenAddressArg,
+		*listenAddressOutputFilepathArg,
+		*metricsListenAddressArg,
+		shutdownConfig,
+	)
 	if err := suiteExecutor.Run(); err != nil {
 		logrus.Errorf("An error occurred running the test suite executor:")
 		fmt.Fprintln(logrus.StandardLogger().Out, err)
+		if stacktrace.RootCause(err) == execution.ErrTestInterrupted {
+			os.Exit(interruptedExitCode)
+		}
 		os.Exit(failureExitCode)
 	}
 	os.Exit(successExitCode)
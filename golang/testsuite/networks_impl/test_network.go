@@ -8,10 +8,11 @@ package networks_impl
 import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/network_setup"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/api"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/datastore"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
 	"strconv"
 	"time"
 )
@@ -58,30 +59,50 @@ func (network *TestNetwork) SetupDatastoreAndTwoApis() error {
 		return stacktrace.NewError("Cannot add API services to network; one or more API services already exists")
 	}
 
-	configFactory := datastore.NewDatastoreContainerConfigFactory(network.datastoreServiceImage)
-	uncastedDatastore, hostPortBindings, checker, err := network.networkCtx.AddService(datastoreServiceId, configFactory)
+	personModifyingApiServiceId := network.getNextApiServiceId()
+	personRetrievingApiServiceId := network.getNextApiServiceId()
+
+	// The network builder takes care of the startup ordering for us: the datastore starts first, and the two API
+	//  services start in parallel once the datastore is available
+	startedServices, err := network_setup.NewNetworkBuilder(
+		network.networkCtx,
+	).WithWaitForStartupPolling(
+		waitForStartupTimeBetweenPolls,
+		waitForStartupMaxNumPolls,
+	).WithService(
+		datastoreServiceId,
+		func(dependencies map[services.ServiceID]services.Service) (services.ContainerConfigFactory, error) {
+			return datastore.NewDatastoreContainerConfigFactory(network.datastoreServiceImage), nil
+		},
+	).WithService(
+		personModifyingApiServiceId,
+		network.createApiConfigFactory,
+		datastoreServiceId,
+	).WithService(
+		personRetrievingApiServiceId,
+		network.createApiConfigFactory,
+		datastoreServiceId,
+	).Build()
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the datastore service")
+		return stacktrace.Propagate(err, "An error occurred starting the datastore and API services")
 	}
-	if err := checker.WaitForStartup(waitForStartupTimeBetweenPolls, waitForStartupMaxNumPolls); err != nil {
-		return stacktrace.Propagate(err, "An error occurred waiting for the datastore service to start")
-	}
-	logrus.Infof("Added datastore service with host port bindings: %+v", hostPortBindings)
-	castedDatastore := uncastedDatastore.(*datastore.DatastoreService)
-	network.datastoreService = castedDatastore
 
-	personModifyingApiService, err := network.addApiService()
+	datastoreService, err := casting.CastService[*datastore.DatastoreService](startedServices[datastoreServiceId])
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the person-modifying API service")
+		return stacktrace.Propagate(err, "An error occurred casting the datastore service")
 	}
-	network.personModifyingApiService = personModifyingApiService
-
-	personRetrievingApiServiceId, err := network.addApiService()
+	personModifyingApiService, err := casting.CastService[*api.ApiService](startedServices[personModifyingApiServiceId])
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the person-retrieving API service")
+		return stacktrace.Propagate(err, "An error occurred casting the person-modifying API service")
+	}
+	personRetrievingApiService, err := casting.CastService[*api.ApiService](startedServices[personRetrievingApiServiceId])
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred casting the person-retrieving API service")
 	}
-	network.personRetrievingApiService = personRetrievingApiServiceId
 
+	network.datastoreService = datastoreService
+	network.personModifyingApiService = personModifyingApiService
+	network.personRetrievingApiService = personRetrievingApiService
 	return nil
 }
 
@@ -104,25 +125,20 @@ func (network TestNetwork) GetPersonRetrievingApiService() (*api.ApiService, err
 // ====================================================================================================
 //                                       Private helper functions
 // ====================================================================================================
-func (network *TestNetwork) addApiService() (*api.ApiService, error) {
-	if network.datastoreService == nil {
-		return nil, stacktrace.NewError("Cannot add API service to network; no datastore service exists")
-	}
-
+func (network *TestNetwork) getNextApiServiceId() services.ServiceID {
 	serviceIdStr := apiServiceIdPrefix + strconv.Itoa(network.nextApiServiceId)
 	network.nextApiServiceId = network.nextApiServiceId + 1
-	serviceId := services.ServiceID(serviceIdStr)
+	return services.ServiceID(serviceIdStr)
+}
 
-	configFactory := api.NewApiContainerConfigFactory(network.apiServiceImage, network.datastoreService)
-	uncastedApiService, hostPortBindings, checker, err := network.networkCtx.AddService(serviceId, configFactory)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the API service")
+func (network *TestNetwork) createApiConfigFactory(dependencies map[services.ServiceID]services.Service) (services.ContainerConfigFactory, error) {
+	uncastedDatastore, found := dependencies[datastoreServiceId]
+	if !found {
+		return nil, stacktrace.NewError("Cannot create API service config; no datastore service dependency was provided")
 	}
-	if err := checker.WaitForStartup(waitForStartupTimeBetweenPolls, waitForStartupMaxNumPolls); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred waiting for the API service to start")
+	castedDatastore, err := casting.CastService[*datastore.DatastoreService](uncastedDatastore)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "Cannot create API service config; an error occurred casting the datastore service dependency")
 	}
-	logrus.Infof("Added API service with host port bindings: %+v", hostPortBindings)
-	castedApiService := uncastedApiService.(*api.ApiService)
-	return castedApiService, nil
+	return api.NewApiContainerConfigFactory(network.apiServiceImage, castedDatastore), nil
 }
-
@@ -1,28 +1,32 @@
 package api
 
 import (
-	"encoding/json"
+	"embed"
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/file_generation"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/datastore"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
-	"os"
 )
 
 const (
 	port = 2434
 
-	configFileKey = "config-file"
+	templatesDirpath = "templates"
+
+	// Generated files are keyed by the name of the template they were rendered from
+	configFileKey = "config.json"
+
+	datastoreTemplateServiceName = "datastore"
 
 	testVolumeMountpoint = "/test-volume"
 )
 
-// Fields are public so we can marshal them as JSON
-type config struct {
-	DatastoreIp string	`json:"datastoreIp"`
-	DatastorePort int	`json:"datastorePort"`
-}
+// The templates are embedded so that they ship inside the testsuite binary, rather than needing to be copied into the
+//  testsuite image
+//go:embed templates
+var templatesFs embed.FS
 
 type ApiContainerConfigFactory struct {
 	image     string
@@ -35,25 +39,14 @@ func NewApiContainerConfigFactory(image string, datastore *datastore.DatastoreSe
 
 
 func (factory ApiContainerConfigFactory) GetCreationConfig(containerIpAddr string) (*services.ContainerCreationConfig, error) {
-	configInitializingFunc := func(fp *os.File) error {
-		logrus.Debugf("Datastore IP: %v , port: %v", factory.datastore.GetIPAddress(), factory.datastore.GetPort())
-		configObj := config{
-			DatastoreIp:   factory.datastore.GetIPAddress(),
-			DatastorePort: factory.datastore.GetPort(),
-		}
-		configBytes, err := json.Marshal(configObj)
-		if err != nil {
-			return stacktrace.Propagate(err, "An error occurred serializing the config to JSON")
-		}
-
-		logrus.Debugf("API config JSON: %v", string(configBytes))
-
-		if _, err := fp.Write(configBytes); err != nil {
-			return stacktrace.Propagate(err, "An error occurred writing the serialized config JSON to file")
-		}
-
-		return nil
+	logrus.Debugf("Datastore IP: %v , port: %v", factory.datastore.GetIPAddress(), factory.datastore.GetPort())
+	templateSet, err := file_generation.ParseTemplateDir(templatesFs, templatesDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the API service's config templates")
 	}
+	templateContext := file_generation.NewTemplateContextBuilder(containerIpAddr).
+		WithServiceAddress(datastoreTemplateServiceName, factory.datastore.GetIPAddress(), factory.datastore.GetPort()).
+		Build()
 
 	result := services.NewContainerCreationConfigBuilder(
 		factory.image,
@@ -61,9 +54,9 @@ func (factory ApiContainerConfigFactory) GetCreationConfig(containerIpAddr strin
 		func(serviceCtx *services.ServiceContext) services.Service { return NewApiService(serviceCtx, port) },
 	).WithUsedPorts(map[string]bool{
 		fmt.Sprintf("%v/tcp", port): true,
-	}).WithGeneratedFiles(map[string]func(*os.File) error{
-		configFileKey: configInitializingFunc,
-	}).Build()
+	}).WithGeneratedFiles(
+		file_generation.NewTemplateSetFileGeneratingFuncs(templateSet, templateContext),
+	).Build()
 
 	return result, nil
 }
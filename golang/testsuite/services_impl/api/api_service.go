@@ -9,8 +9,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/probes"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
 )
@@ -42,28 +42,10 @@ func NewApiService(serviceCtx *services.ServiceContext, port int) *ApiService {
 //                              Service interface methods
 // ===========================================================================================
 func (service ApiService) IsAvailable() bool {
-	url := fmt.Sprintf("http://%v:%v/%v", service.serviceCtx.GetIPAddress(), service.port, healthcheckUrlSlug)
-	resp, err := http.Get(url)
-	if err != nil {
-		logrus.Debugf("An HTTP error occurred when polliong the health endpoint: %v", err)
-		return false
-	}
-	if resp.StatusCode != http.StatusOK {
-		logrus.Debugf("Received non-OK status code: %v", resp.StatusCode)
-		return false
-	}
-
-	body := resp.Body
-	defer body.Close()
-
-	bodyBytes, err := ioutil.ReadAll(body)
-	if err != nil {
-		logrus.Debugf("An error occurred reading the response body: %v", err)
-		return false
-	}
-	bodyStr := string(bodyBytes)
-
-	return bodyStr == healthyValue
+	return probes.IsAvailable(
+		service.serviceCtx,
+		probes.NewHttpProbe(service.port, healthcheckUrlSlug, http.StatusOK, healthyValue),
+	)
 }
 
 // ===========================================================================================
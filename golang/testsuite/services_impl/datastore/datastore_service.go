@@ -8,8 +8,8 @@ package datastore
 import (
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/probes"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -36,29 +36,10 @@ func NewDatastoreService(serviceCtx *services.ServiceContext, port int) *Datasto
 //                              Service interface methods
 // ===========================================================================================
 func (service DatastoreService) IsAvailable() bool {
-	service.serviceCtx.GetIPAddress()
-	url := fmt.Sprintf("http://%v:%v/%v", service.serviceCtx.GetIPAddress(), service.port, healthcheckUrlSlug)
-	resp, err := http.Get(url)
-	if err != nil {
-		logrus.Debugf("An HTTP error occurred when polliong the health endpoint: %v", err)
-		return false
-	}
-	if resp.StatusCode != http.StatusOK {
-		logrus.Debugf("Received non-OK status code: %v", resp.StatusCode)
-		return false
-	}
-
-	body := resp.Body
-	defer body.Close()
-
-	bodyBytes, err := ioutil.ReadAll(body)
-	if err != nil {
-		logrus.Debugf("An error occurred reading the response body: %v", err)
-		return false
-	}
-	bodyStr := string(bodyBytes)
-
-	return bodyStr == healthyValue
+	return probes.IsAvailable(
+		service.serviceCtx,
+		probes.NewHttpProbe(service.port, healthcheckUrlSlug, http.StatusOK, healthyValue),
+	)
 }
 
 // ===========================================================================================
@@ -1,7 +1,9 @@
 package exec_cmd_test
 
 import (
+	"bytes"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/service_files"
 	"github.com/palantir/stacktrace"
 )
 
@@ -26,3 +28,18 @@ func (self ExecCmdTestService) RunExecCmd(command []string) (int32, *[]byte, err
 	}
 	return exitCode, logOutput, nil
 }
+
+func (self ExecCmdTestService) UploadFile(contents []byte, destFilepath string) error {
+	if err := service_files.UploadFile(self.serviceContext, bytes.NewReader(contents), destFilepath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred uploading file '%v'", destFilepath)
+	}
+	return nil
+}
+
+func (self ExecCmdTestService) DownloadAsTar(srcPath string) ([]byte, error) {
+	tarBuffer := &bytes.Buffer{}
+	if err := service_files.DownloadAsTar(self.serviceContext, srcPath, tarBuffer); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred downloading '%v'", srcPath)
+	}
+	return tarBuffer.Bytes(), nil
+}
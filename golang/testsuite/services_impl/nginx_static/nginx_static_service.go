@@ -8,6 +8,7 @@ package nginx_static
 import (
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/probes"
 	"github.com/palantir/stacktrace"
 	"io/ioutil"
 	"net/http"
@@ -25,12 +26,11 @@ func NewNginxStaticService(serviceCtx *services.ServiceContext) *NginxStaticServ
 }
 
 func (self NginxStaticService) IsAvailable() bool {
-	_, err := http.Get(fmt.Sprintf("%v:%v", self.serviceCtx.GetIPAddress(), listenPort))
-	return err != nil
+	return probes.IsAvailable(self.serviceCtx, probes.NewTcpProbe(listenPort))
 }
 
 func (self NginxStaticService) GetFileContents(filename string) (string, error) {
-	resp, err := http.Get(fmt.Sprintf("%v:%v/%v", self.serviceCtx.GetIPAddress(), listenPort, filename))
+	resp, err := http.Get(fmt.Sprintf("http://%v:%v/%v", self.serviceCtx.GetIPAddress(), listenPort, filename))
 	if err != nil {
 		return "", stacktrace.Propagate(err, "An error occurred getting the contents of file '%v'", filename)
 	}
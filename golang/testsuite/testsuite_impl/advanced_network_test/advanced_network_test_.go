@@ -30,7 +30,7 @@ func (test *AdvancedNetworkTest) Configure(builder *testsuite.TestConfigurationB
 	builder.WithSetupTimeoutSeconds(60).WithRunTimeoutSeconds(60)
 }
 
-func (test *AdvancedNetworkTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test *AdvancedNetworkTest) Setup(networkCtx *networks.NetworkContext) (*networks_impl.TestNetwork, error) {
 	network := networks_impl.NewTestNetwork(networkCtx, test.datastoreServiceImage, test.apiServiceImage)
 	// Note how setup logic has been pushed into a custom Network implementation, to make test-writing easy
 	if err := network.SetupDatastoreAndTwoApis(); err != nil {
@@ -39,13 +39,12 @@ func (test *AdvancedNetworkTest) Setup(networkCtx *networks.NetworkContext) (net
 	return network, nil
 }
 
-func (test *AdvancedNetworkTest) Run(network networks.Network) error {
-	castedNetwork := network.(*networks_impl.TestNetwork)
-	personModifier, err := castedNetwork.GetPersonModifyingApiService()
+func (test *AdvancedNetworkTest) Run(network *networks_impl.TestNetwork) error {
+	personModifier, err := network.GetPersonModifyingApiService()
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the person-modifying API service")
 	}
-	personRetriever, err := castedNetwork.GetPersonRetrievingApiService()
+	personRetriever, err := network.GetPersonRetrievingApiService()
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the person-retrieving API service")
 	}
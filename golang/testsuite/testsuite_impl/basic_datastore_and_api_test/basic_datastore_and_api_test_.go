@@ -8,6 +8,7 @@ package basic_datastore_and_api_test
 import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/api"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/datastore"
@@ -40,7 +41,7 @@ func (b BasicDatastoreAndApiTest) Configure(builder *testsuite.TestConfiguration
 	builder.WithSetupTimeoutSeconds(60).WithRunTimeoutSeconds(60)
 }
 
-func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	datastoreConfigFactory := datastore.NewDatastoreContainerConfigFactory(b.datstoreImage)
 	uncastedDatastoreSvc, datastoreSvcHostPortBindings, datastoreChecker, err := networkCtx.AddService(datastoreServiceId, datastoreConfigFactory)
 	if err != nil {
@@ -51,8 +52,10 @@ func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (ne
 	}
 	logrus.Infof("Added datastore service with host port bindings: %+v", datastoreSvcHostPortBindings)
 
-	// Go doesn't have generics so we need to do this cast
-	datastoreSvc := uncastedDatastoreSvc.(*datastore.DatastoreService)
+	datastoreSvc, err := casting.CastService[*datastore.DatastoreService](uncastedDatastoreSvc)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred casting the datastore service")
+	}
 
 	apiConfigFactory := api.NewApiContainerConfigFactory(b.apiImage, datastoreSvc)
 	_, apiSvcHostPortBindings, apiChecker, err := networkCtx.AddService(apiServiceId, apiConfigFactory)
@@ -67,15 +70,11 @@ func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (ne
 }
 
 
-func (b BasicDatastoreAndApiTest) Run(network networks.Network) error {
-	// Go doesn't have generics so we have to do this cast first
-	castedNetwork := network.(*networks.NetworkContext)
-
-	uncastedApiService, err := castedNetwork.GetService(apiServiceId)
+func (b BasicDatastoreAndApiTest) Run(network *networks.NetworkContext) error {
+	apiService, err := casting.GetService[*api.ApiService](network, apiServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the API service")
 	}
-	apiService := uncastedApiService.(*api.ApiService)
 
 	logrus.Infof("Verifying that person with test ID '%v' doesn't already exist...", testPersonId)
 	if _, err = apiService.GetPerson(testPersonId); err == nil {
@@ -8,6 +8,7 @@ package basic_datastore_test
 import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/datastore"
 	"github.com/palantir/stacktrace"
@@ -37,7 +38,7 @@ func (test BasicDatastoreTest) Configure(builder *testsuite.TestConfigurationBui
 	builder.WithSetupTimeoutSeconds(60).WithRunTimeoutSeconds(60)
 }
 
-func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	datastoreConfigFactory := datastore.NewDatastoreContainerConfigFactory(test.datastoreImage)
 	_, hostPortBindings, availabilityChecker, err := networkCtx.AddService(datastoreServiceId, datastoreConfigFactory)
 	if err != nil {
@@ -50,18 +51,12 @@ func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (netwo
 	return networkCtx, nil
 }
 
-func (test BasicDatastoreTest) Run(network networks.Network) error {
-	// Necessary because Go doesn't have generics
-	castedNetwork := network.(*networks.NetworkContext)
-
-	uncastedService, err := castedNetwork.GetService(datastoreServiceId)
+func (test BasicDatastoreTest) Run(network *networks.NetworkContext) error {
+	castedService, err := casting.GetService[*datastore.DatastoreService](network, datastoreServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the datastore service")
 	}
 
-	// Necessary again due to no Go generics
-	castedService := uncastedService.(*datastore.DatastoreService)
-
 	logrus.Infof("Verifying that key '%v' doesn't already exist...", testKey)
 	exists, err := castedService.Exists(testKey)
 	if err != nil {
@@ -0,0 +1,119 @@
+package copy_files_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/exec_cmd_test"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+const (
+	copyFilesTestImage = "alpine:3.12.4"
+	testServiceId      = "test"
+
+	successExitCode int32 = 0
+
+	waitForStartupTimeBetweenPolls = 1 * time.Second
+	waitForStartupMaxPolls = 10
+
+	uploadDirpath = "/tmp/copy-files-test"
+	uploadFilepath = uploadDirpath + "/uploaded.txt"
+	// The downloaded TAR will contain the directory's name as its top-level entry
+	expectedTarEntryName = "copy-files-test/uploaded.txt"
+
+	testFileContents = "copy-files-test-contents"
+)
+
+type CopyFilesTest struct {}
+
+func (c CopyFilesTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(30).WithRunTimeoutSeconds(30)
+}
+
+func (c CopyFilesTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
+	configFactory := exec_cmd_test.NewExecCmdTestContainerConfigFactory(copyFilesTestImage)
+	_, _, checker, err := networkCtx.AddService(testServiceId, configFactory)
+	if err != nil {
+		return nil, stacktrace.Propagate(
+			err,
+			"An error occurred starting service '%v'",
+			testServiceId)
+	}
+	if err := checker.WaitForStartup(waitForStartupTimeBetweenPolls, waitForStartupMaxPolls); err != nil {
+		return nil, stacktrace.Propagate(
+			err,
+			"An error occurred waiting for service '%v' to start up",
+			testServiceId)
+	}
+	return networkCtx, nil
+}
+
+func (c CopyFilesTest) Run(network *networks.NetworkContext) error {
+	castedService, err := casting.GetService[*exec_cmd_test.ExecCmdTestService](network, testServiceId)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting service with ID '%v'", testServiceId)
+	}
+
+	logrus.Infof("Uploading a file to '%v' inside the running service...", uploadFilepath)
+	if err := castedService.UploadFile([]byte(testFileContents), uploadFilepath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred uploading the test file")
+	}
+	logrus.Info("Uploaded file")
+
+	logrus.Info("Verifying the uploaded file's contents from inside the service...")
+	exitCode, logOutput, err := castedService.RunExecCmd([]string{"cat", uploadFilepath})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the uploaded file inside the service")
+	}
+	if exitCode != successExitCode {
+		return stacktrace.NewError("Reading the uploaded file inside the service returned unsuccessful exit code %v", exitCode)
+	}
+	if string(*logOutput) != testFileContents {
+		return stacktrace.NewError("Uploaded file contents '%v' != expected contents '%v'", string(*logOutput), testFileContents)
+	}
+	logrus.Info("Uploaded file contents verified")
+
+	logrus.Infof("Downloading directory '%v' out of the service as a TAR...", uploadDirpath)
+	tarBytes, err := castedService.DownloadAsTar(uploadDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred downloading the upload directory")
+	}
+	downloadedContents, err := getTarEntryContents(tarBytes, expectedTarEntryName)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the uploaded file from the downloaded TAR")
+	}
+	if downloadedContents != testFileContents {
+		return stacktrace.NewError("Downloaded file contents '%v' != expected contents '%v'", downloadedContents, testFileContents)
+	}
+	logrus.Info("Downloaded file contents verified")
+
+	return nil
+}
+
+func getTarEntryContents(tarBytes []byte, entryName string) (string, error) {
+	tarReader := tar.NewReader(bytes.NewReader(tarBytes))
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return "", stacktrace.NewError("No entry '%v' was found in the TAR", entryName)
+		}
+		if err != nil {
+			return "", stacktrace.Propagate(err, "An error occurred reading the next TAR entry")
+		}
+		if header.Name != entryName {
+			continue
+		}
+		contentBytes, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return "", stacktrace.Propagate(err, "An error occurred reading TAR entry '%v'", entryName)
+		}
+		return string(contentBytes), nil
+	}
+}
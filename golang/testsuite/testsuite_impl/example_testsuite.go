@@ -6,10 +6,13 @@
 package testsuite_impl
 
 import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/advanced_network_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/basic_datastore_and_api_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/basic_datastore_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/copy_files_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/exec_command_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/files_artifact_mounting_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/network_partition_test"
@@ -36,14 +39,20 @@ func NewExampleTestsuite(myCustomServiceImage string, apiServiceImage string, da
 
 func (suite ExampleTestsuite) GetTests() map[string]testsuite.Test {
 	tests := map[string]testsuite.Test{
-		"basicDatastoreTest": basic_datastore_test.NewBasicDatastoreTest(suite.datastoreServiceImage),
-		"basicDatastoreAndApiTest": basic_datastore_and_api_test.NewBasicDatastoreAndApiTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
+		"basicDatastoreTest": testsuite.AdaptTypedTest[*networks.NetworkContext](
+			basic_datastore_test.NewBasicDatastoreTest(suite.datastoreServiceImage),
 		),
-		"advancedNetworkTest": advanced_network_test.NewAdvancedNetworkTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
+		"basicDatastoreAndApiTest": testsuite.AdaptTypedTest[*networks.NetworkContext](
+			basic_datastore_and_api_test.NewBasicDatastoreAndApiTest(
+				suite.datastoreServiceImage,
+				suite.apiServiceImage,
+			),
+		),
+		"advancedNetworkTest": testsuite.AdaptTypedTest[*networks_impl.TestNetwork](
+			advanced_network_test.NewAdvancedNetworkTest(
+				suite.datastoreServiceImage,
+				suite.apiServiceImage,
+			),
 		),
 	}
 
@@ -52,12 +61,17 @@ func (suite ExampleTestsuite) GetTests() map[string]testsuite.Test {
 	//  to you) are run
 	// Feel free to delete these tests as you see fit
 	if suite.isKurtosisCoreDevMode {
-		tests["networkPartitionTest"] = network_partition_test.NewNetworkPartitionTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
+		tests["networkPartitionTest"] = testsuite.AdaptTypedTest[*networks.NetworkContext](
+			network_partition_test.NewNetworkPartitionTest(
+				suite.datastoreServiceImage,
+				suite.apiServiceImage,
+			),
+		)
+		tests["filesArtifactMountingTest"] = testsuite.AdaptTypedTest[*networks.NetworkContext](
+			files_artifact_mounting_test.FilesArtifactMountingTest{},
 		)
-		tests["filesArtifactMountingTest"] = files_artifact_mounting_test.FilesArtifactMountingTest{}
-		tests["execCommandTest"] = exec_command_test.ExecCommandTest{}
+		tests["execCommandTest"] = testsuite.AdaptTypedTest[*networks.NetworkContext](exec_command_test.ExecCommandTest{})
+		tests["copyFilesTest"] = testsuite.AdaptTypedTest[*networks.NetworkContext](copy_files_test.CopyFilesTest{})
 	}
 
 	return tests
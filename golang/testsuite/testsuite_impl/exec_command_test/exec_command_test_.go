@@ -3,6 +3,7 @@ package exec_command_test
 import (
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/exec_cmd_test"
 	"github.com/palantir/stacktrace"
@@ -41,7 +42,7 @@ func (e ExecCommandTest) Configure(builder *testsuite.TestConfigurationBuilder)
 	builder.WithSetupTimeoutSeconds(30).WithRunTimeoutSeconds(30)
 }
 
-func (e ExecCommandTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (e ExecCommandTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	configFactory := exec_cmd_test.NewExecCmdTestContainerConfigFactory(execCmdTestImage)
 	_, _, checker, err := networkCtx.AddService(testServiceId, configFactory)
 	if err != nil {
@@ -59,14 +60,11 @@ func (e ExecCommandTest) Setup(networkCtx *networks.NetworkContext) (networks.Ne
 	return networkCtx, nil
 }
 
-func (e ExecCommandTest) Run(uncastedNetwork networks.Network) error {
-	network := uncastedNetwork.(*networks.NetworkContext)
-
-	uncastedService, err := network.GetService(testServiceId)
+func (e ExecCommandTest) Run(network *networks.NetworkContext) error {
+	castedService, err := casting.GetService[*exec_cmd_test.ExecCmdTestService](network, testServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting service with ID '%v'", testServiceId)
 	}
-	castedService := uncastedService.(*exec_cmd_test.ExecCmdTestService)
 
 	logrus.Infof("Running exec command '%v' that should return a successful exit code...", execCommandThatShouldWork)
 	shouldWorkExitCode, _, err := castedService.RunExecCmd(execCommandThatShouldWork)
@@ -8,6 +8,7 @@ package files_artifact_mounting_test
 import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/nginx_static"
 	"github.com/palantir/stacktrace"
@@ -46,7 +47,7 @@ func (f FilesArtifactMountingTest) Configure(builder *testsuite.TestConfiguratio
 	)
 }
 
-func (f FilesArtifactMountingTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (f FilesArtifactMountingTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	configFactory := nginx_static.NewNginxStaticContainerConfigFactory(testFilesArtifactId)
 	_, hostPortBindings, availabilityChecker, err := networkCtx.AddService(fileServerServiceId, configFactory)
 	if err != nil {
@@ -59,21 +60,12 @@ func (f FilesArtifactMountingTest) Setup(networkCtx *networks.NetworkContext) (n
 	return networkCtx, nil
 }
 
-func (f FilesArtifactMountingTest) Run(network networks.Network) error {
-	// Only necessary because Go doesn't have generics
-	castedNetwork := network.(*networks.NetworkContext)
-
-	uncastedService, err := castedNetwork.GetService(fileServerServiceId)
+func (f FilesArtifactMountingTest) Run(network *networks.NetworkContext) error {
+	castedService, err := casting.GetService[*nginx_static.NginxStaticService](network, fileServerServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred retrieving the fileserver service")
 	}
 
-	// Only necessary because Go doesn't have generics
-	castedService, castErrOccurred := uncastedService.(*nginx_static.NginxStaticService)
-	if castErrOccurred {
-		return stacktrace.Propagate(err, "An error occurred casting the file server service API")
-	}
-
 	file1Contents, err := castedService.GetFileContents(file1Filename)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting file 1's contents")
@@ -7,6 +7,7 @@ package my_custom_test
 import (
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/networks"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/my_custom_service"
 	"github.com/palantir/stacktrace"
@@ -29,7 +30,7 @@ func (test MyCustomTest) Configure(builder *testsuite.TestConfigurationBuilder)
 	builder.WithSetupTimeoutSeconds(30).WithRunTimeoutSeconds(30)
 }
 
-func (test MyCustomTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test MyCustomTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	logrus.Infof("Setting up custom test.")
 	/*
 		NEW USER ONBOARDING:
@@ -47,18 +48,12 @@ func (test MyCustomTest) Setup(networkCtx *networks.NetworkContext) (networks.Ne
 	return networkCtx, nil
 }
 
-func (test MyCustomTest) Run(uncastedNetwork networks.Network) error {
+func (test MyCustomTest) Run(network *networks.NetworkContext) error {
 	logrus.Infof("Running custom test.")
-	// Necessary because Go doesn't have generics
-	castedNetwork := uncastedNetwork.(*networks.NetworkContext)
-
-	uncastedService, err := castedNetwork.GetService(myCustomServiceID)
+	castedService, err := casting.GetService[*my_custom_service.MyCustomService](network, myCustomServiceID)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred getting the datastore service")
+		return stacktrace.Propagate(err, "An error occurred getting the custom service")
 	}
-
-	// Necessary again due to no Go generics
-	castedService := uncastedService.(*my_custom_service.MyCustomService)
 	logrus.Infof("Service is available: %v", castedService.IsAvailable())
 
 	/*
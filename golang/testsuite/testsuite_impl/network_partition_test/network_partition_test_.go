@@ -9,6 +9,7 @@ import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/core_api_bindings"
 	"github.com/kurtosis-tech/kurtosis-client/golang/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/casting"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/testsuite"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/api"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl/datastore"
@@ -51,7 +52,7 @@ func (test NetworkPartitionTest) Configure(builder *testsuite.TestConfigurationB
 }
 
 // Instantiates the network with no partition and one person in the datatstore
-func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	datastoreConfigFactory := datastore.NewDatastoreContainerConfigFactory(test.datstoreImage)
 	uncastedDatastoreSvc, datastoreSvcHostPortBindings, datastoreChecker, err := networkCtx.AddService(datastoreServiceId, datastoreConfigFactory)
 	if err != nil {
@@ -62,8 +63,10 @@ func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (net
 	}
 	logrus.Infof("Added datastore service with host port bindings: %+v", datastoreSvcHostPortBindings)
 
-	// Go doesn't have generics so we need to do this cast
-	datastoreSvc := uncastedDatastoreSvc.(*datastore.DatastoreService)
+	datastoreSvc, err := casting.CastService[*datastore.DatastoreService](uncastedDatastoreSvc)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred casting the datastore service")
+	}
 
 	apiSvc, err := test.addApiService(networkCtx, api1ServiceId, defaultPartitionId, datastoreSvc)
 	if err != nil {
@@ -81,23 +84,19 @@ func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (net
 }
 
 
-func (test NetworkPartitionTest) Run(network networks.Network) error {
-	// Go doesn't have generics so we have to do this cast first
-	castedNetwork := network.(*networks.NetworkContext)
-
+func (test NetworkPartitionTest) Run(network *networks.NetworkContext) error {
 
 	logrus.Info("Partitioning API and datastore services off from each other...")
-	if err := repartitionNetwork(castedNetwork, true, false); err != nil {
+	if err := repartitionNetwork(network, true, false); err != nil {
 		return stacktrace.Propagate(err, "An error occurred repartitioning the network to block access between API <-> datastore")
 	}
 	logrus.Info("Repartition complete")
 
 	logrus.Info("Incrementing books read via API 1 while partition is in place, to verify no comms are possible...")
-	uncastedApi1Service, err := castedNetwork.GetService(api1ServiceId)
+	api1Service, err := casting.GetService[*api.ApiService](network, api1ServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the API 1 service interface")
 	}
-	api1Service := uncastedApi1Service.(*api.ApiService) // Necessary because Go doesn't have generics
 	if err := api1Service.IncrementBooksRead(testPersonId); err == nil {
 		return stacktrace.NewError("Expected the book increment call via API 1 to fail due to the network " +
 			"partition between API and datastore services, but no error was thrown")
@@ -107,15 +106,15 @@ func (test NetworkPartitionTest) Run(network networks.Network) error {
 
 	// Adding another API service while the partition is in place ensures that partitiong works even when you add a node
 	logrus.Info("Adding second API container, to ensure adding a network under partition works...")
-	uncastedDatastoreSvc, err := castedNetwork.GetService(datastoreServiceId)
+	datastoreSvc, err := casting.GetService[*datastore.DatastoreService](network, datastoreServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the datastore service interface")
 	}
 	api2Service, err := test.addApiService(
-		castedNetwork,
+		network,
 		api2ServiceId,
 		apiPartitionId,
-		uncastedDatastoreSvc.(*datastore.DatastoreService))
+		datastoreSvc)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred adding the second API service to the network")
 	}
@@ -131,7 +130,7 @@ func (test NetworkPartitionTest) Run(network networks.Network) error {
 
 	// Now, open the network back up
 	logrus.Info("Repartitioning to heal partition between API and datastore...")
-	if err := repartitionNetwork(castedNetwork, false, true); err != nil {
+	if err := repartitionNetwork(network, false, true); err != nil {
 		return stacktrace.Propagate(err, "An error occurred healing the partition")
 	}
 	logrus.Info("Partition healed successfully")
@@ -177,7 +176,11 @@ func (test NetworkPartitionTest) addApiService(
 		return nil, stacktrace.Propagate(err, "An error occurred waiting for the API service to start")
 	}
 	logrus.Infof("Added API service '%v' with host port bindings: %+v", serviceId, hostPortBindings)
-	return uncastedApiSvc.(*api.ApiService), nil
+	apiSvc, err := casting.CastService[*api.ApiService](uncastedApiSvc)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred casting the API service")
+	}
+	return apiSvc, nil
 }
 
 /*
@@ -15,6 +15,10 @@ CHANGELOG_TBD_LINE="# TBD"
 CHANGELOG_TBD_LINE_PATTERN="^${CHANGELOG_TBD_LINE}$"
 EXPECTED_NUM_VERSION_FRAGMENTS=3   # We expected X.Y.Z versions
 
+GOLANG_DIRNAME="golang"
+GOLANG_LIB_VERSION_REL_FILEPATH="lib/rpc_api/rpc_api_consts/rpc_api_consts.go"   # Relative to the Golang dir
+GOLANG_LIB_VERSION_PATTERN='LibVersion = "[0-9.]*"'
+
 
 
 # ==========================================================================================
@@ -60,9 +64,25 @@ function make_shared_pre_release_modifications() {
     fi
 }
 
-# NOTE: Go doesn't have any pre-release steps
+function make_golang_pre_release_modifications() {
+    new_version="${1}"
+
+    # Update the lib version that the testsuite reports in its metadata
+    lib_version_filepath="${root_dirpath}/${GOLANG_DIRNAME}/${GOLANG_LIB_VERSION_REL_FILEPATH}"
+    num_lib_version_lines="$(grep -c "${GOLANG_LIB_VERSION_PATTERN}" "${lib_version_filepath}" || true)"
+    if [ "${num_lib_version_lines}" -ne 1 ]; then
+        echo "Error: Expected exactly one line matching pattern '${GOLANG_LIB_VERSION_PATTERN}' in '${lib_version_filepath}' but found ${num_lib_version_lines}" >&2
+        return 1
+    fi
+    if ! sed -i '' "s/${GOLANG_LIB_VERSION_PATTERN}/LibVersion = \"${new_version}\"/" "${lib_version_filepath}"; then
+        echo "Error: Could not update the lib version in file '${lib_version_filepath}' to '${new_version}'" >&2
+        return 1
+    fi
+}
+
 pre_release_functions=(
     "make_shared_pre_release_modifications"
+    "make_golang_pre_release_modifications"
 )
 
 